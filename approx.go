@@ -0,0 +1,264 @@
+package itertools
+
+import (
+	"container/heap"
+	"hash/maphash"
+	"iter"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// The functions below trade exactness for bounded memory, so that they can
+// process streams too large to be held in a map. Values are hashed with
+// hash/maphash using a random seed, so the results may vary slightly between runs.
+
+const (
+	// MinPrecision and MaxPrecision bound the precision accepted by ApproxCountDistinct.
+	MinPrecision = 4
+	MaxPrecision = 18
+
+	precisionPanicMessage = "itertools: precision must be between 4 and 18"
+	fpRatePanicMessage    = "itertools: false positive rate must be between 0 and 1"
+)
+
+// ApproxCountDistinct estimates the number of distinct elements of `iter`
+// using the HyperLogLog algorithm. It uses 2^precision bytes of memory
+// regardless of the number of elements, and its typical relative error
+// is 1.04/sqrt(2^precision): about 1.6% for precision 12 and 0.4% for precision 16.
+//
+// It panics if `precision` isn't between MinPrecision and MaxPrecision.
+//
+// Example:
+//
+//	users := Map(Range(0, 1_000_000, 1), func(i int) int { return i % 50_000 })
+//	fmt.Println(ApproxCountDistinct(users, 14)) // about 50000
+func ApproxCountDistinct[V comparable](iter iter.Seq[V], precision int) int {
+	if precision < MinPrecision || precision > MaxPrecision {
+		panic(precisionPanicMessage)
+	}
+
+	m := 1 << precision
+	registers := make([]uint8, m)
+	seed := maphash.MakeSeed()
+	for v := range iter {
+		h := maphash.Comparable(seed, v)
+		i := h >> (64 - precision)
+		// The sentinel bit caps the rank when the remaining bits are all zero.
+		rank := uint8(bits.LeadingZeros64(h<<precision|1<<(precision-1)) + 1)
+		registers[i] = max(registers[i], rank)
+	}
+
+	sum, zeros := 0.0, 0
+	for _, r := range registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	var alpha float64
+	switch m {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/float64(m))
+	}
+	estimate := alpha * float64(m) * float64(m) / sum
+	if estimate <= 2.5*float64(m) && zeros > 0 {
+		// Linear counting is more precise for small cardinalities.
+		estimate = float64(m) * math.Log(float64(m)/float64(zeros))
+	}
+	return int(math.Round(estimate))
+}
+
+// bloom is a Bloom filter over values of type V.
+type bloom[V comparable] struct {
+	bits         []uint64
+	m            uint64
+	k            int
+	seed1, seed2 maphash.Seed
+}
+
+// newBloom creates a Bloom filter sized for `n` values with the false positive rate `p`.
+func newBloom[V comparable](n int, p float64) *bloom[V] {
+	n = max(n, 1)
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	k := max(int(math.Round(float64(m)/float64(n)*math.Ln2)), 1)
+	return &bloom[V]{
+		bits:  make([]uint64, (m+63)/64),
+		m:     m,
+		k:     k,
+		seed1: maphash.MakeSeed(),
+		seed2: maphash.MakeSeed(),
+	}
+}
+
+// add adds `v` to the filter and reports whether it may have been there already.
+func (b *bloom[V]) add(v V) bool {
+	// Double hashing derives all k positions from two hashes.
+	h1 := maphash.Comparable(b.seed1, v)
+	h2 := maphash.Comparable(b.seed2, v) | 1
+	present := true
+	for i := range b.k {
+		pos := (h1 + uint64(i)*h2) % b.m
+		word, mask := pos/64, uint64(1)<<(pos%64)
+		if b.bits[word]&mask == 0 {
+			present = false
+			b.bits[word] |= mask
+		}
+	}
+	return present
+}
+
+// ApproxDistinct returns a sequence of the elements of `iter` with duplicates removed,
+// using a Bloom filter instead of remembering every element seen.
+// The filter is sized for `expected` distinct elements, so that at most
+// a `fpRate` fraction of new elements are mistaken for duplicates and dropped.
+// Duplicates are never yielded. The rate grows if there are more distinct
+// elements than expected.
+//
+// It uses about -expected*ln(fpRate)/ln(2)^2 bits of memory: 1.2 MB for
+// a million elements at 1%.
+//
+// It panics if `fpRate` isn't between 0 and 1.
+//
+// Example:
+//
+//	ids := FromElements(3, 1, 3, 2, 1)
+//	for id := range ApproxDistinct(ids, 1000, 0.01) {
+//		fmt.Println(id) // 3, 1, 2
+//	}
+func ApproxDistinct[V comparable](iter iter.Seq[V], expected int, fpRate float64) iter.Seq[V] {
+	if !(fpRate > 0 && fpRate < 1) {
+		panic(fpRatePanicMessage)
+	}
+
+	return checked(func(yield func(V) bool) {
+		filter := newBloom[V](expected, fpRate)
+		for v := range iter {
+			if filter.add(v) {
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+const (
+	sketchDepth    = 5
+	sketchMinWidth = 1024
+)
+
+// countMin is a count-min sketch estimating the frequencies of values of type V.
+type countMin[V comparable] struct {
+	width  uint64
+	counts [sketchDepth][]int
+	seeds  [sketchDepth]maphash.Seed
+}
+
+func newCountMin[V comparable](width int) *countMin[V] {
+	s := &countMin[V]{width: uint64(width)}
+	for i := range sketchDepth {
+		s.counts[i] = make([]int, width)
+		s.seeds[i] = maphash.MakeSeed()
+	}
+	return s
+}
+
+// add counts one more occurrence of `v` and returns the estimated count of it,
+// which is never less than the true count.
+func (s *countMin[V]) add(v V) int {
+	est := math.MaxInt
+	for i := range sketchDepth {
+		j := maphash.Comparable(s.seeds[i], v) % s.width
+		s.counts[i][j]++
+		est = min(est, s.counts[i][j])
+	}
+	return est
+}
+
+// hitters is a min-heap of the candidate heavy hitters ordered by their estimated counts.
+type hitters[V comparable] struct {
+	items []pair[V, int]
+	index map[V]int
+}
+
+func (h *hitters[V]) Len() int           { return len(h.items) }
+func (h *hitters[V]) Less(i, j int) bool { return h.items[i].v < h.items[j].v }
+func (h *hitters[V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].k] = i
+	h.index[h.items[j].k] = j
+}
+func (h *hitters[V]) Push(x any) {
+	p := x.(pair[V, int])
+	h.index[p.k] = len(h.items)
+	h.items = append(h.items, p)
+}
+func (h *hitters[V]) Pop() any {
+	p := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	delete(h.index, p.k)
+	return p
+}
+
+// HeavyHitters returns a sequence of the `k` most frequent elements of `iter`
+// paired with their estimated counts, from the most frequent one.
+// Frequencies are tracked by a count-min sketch, so memory is bounded by `k`
+// rather than by the number of distinct elements.
+//
+// Estimated counts are never less than the true ones. With high probability
+// they exceed them by at most e*N/max(1024, 16*k), where N is the length of `iter`.
+// Elements whose frequencies are within that margin may be ranked in a different order.
+//
+// It panics if `k` is negative.
+//
+// Example:
+//
+//	words := FromElements("a", "b", "a", "c", "a", "b")
+//	for w, n := range HeavyHitters(words, 2) {
+//		fmt.Println(w, n) // "a" 3, "b" 2
+//	}
+func HeavyHitters[V comparable](iter iter.Seq[V], k int) iter.Seq2[V, int] {
+	if k < 0 {
+		panic(countPanicMessage)
+	}
+
+	return checked2(func(yield func(V, int) bool) {
+		if k == 0 {
+			return
+		}
+		sketch := newCountMin[V](max(sketchMinWidth, 16*k))
+		top := &hitters[V]{index: make(map[V]int, k)}
+		for v := range iter {
+			est := sketch.add(v)
+			switch i, ok := top.index[v]; {
+			case ok:
+				top.items[i].v = est
+				heap.Fix(top, i)
+			case top.Len() < k:
+				heap.Push(top, pair[V, int]{v, est})
+			case est > top.items[0].v:
+				heap.Pop(top)
+				heap.Push(top, pair[V, int]{v, est})
+			}
+		}
+
+		slices.SortStableFunc(top.items, func(a, b pair[V, int]) int {
+			return b.v - a.v
+		})
+		for _, p := range top.items {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package itertools
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestApproxCountDistinct(t *testing.T) {
+	type testCase struct {
+		name      string
+		distinct  int
+		repeats   int
+		precision int
+	}
+	tests := []testCase{
+		{
+			name:      "small cardinality",
+			distinct:  100,
+			repeats:   5,
+			precision: 12,
+		},
+		{
+			name:      "large cardinality",
+			distinct:  200_000,
+			repeats:   2,
+			precision: 14,
+		},
+		{
+			name:      "low precision",
+			distinct:  10_000,
+			repeats:   1,
+			precision: MinPrecision,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq := Map(Range(0, tt.distinct*tt.repeats, 1), func(i int) string {
+				return "user-" + strconv.Itoa(i%tt.distinct)
+			})
+			got := ApproxCountDistinct(seq, tt.precision)
+
+			// Five standard errors keep the test from flaking.
+			stdErr := 1.04 / math.Sqrt(float64(int(1)<<tt.precision))
+			if relErr := math.Abs(float64(got-tt.distinct)) / float64(tt.distinct); relErr > 5*stdErr {
+				t.Errorf("ApproxCountDistinct() = %v, want %v within %.1f%%", got, tt.distinct, 500*stdErr)
+			}
+		})
+	}
+
+	if got := ApproxCountDistinct(FromElements[int](), 10); got != 0 {
+		t.Errorf("ApproxCountDistinct() = %v, want 0", got)
+	}
+}
+
+func TestApproxCountDistinctPanics(t *testing.T) {
+	for _, precision := range []int{MinPrecision - 1, MaxPrecision + 1} {
+		if panicked, msg := panics(func() { ApproxCountDistinct(FromElements(1), precision) }); !panicked || msg != precisionPanicMessage {
+			t.Errorf("ApproxCountDistinct(precision=%d) panicked = %v with %q, want %q", precision, panicked, msg, precisionPanicMessage)
+		}
+	}
+}
+
+func TestApproxDistinct(t *testing.T) {
+	got := slices.Collect(ApproxDistinct(FromElements(3, 1, 3, 2, 1, 3), 100, 0.01))
+	if want := []int{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ApproxDistinct() = %v, want %v", got, want)
+	}
+}
+
+func TestApproxDistinctFalsePositiveRate(t *testing.T) {
+	const n, fpRate = 50_000, 0.01
+	// Every element appears twice, so duplicates must all be dropped,
+	// and only false positives may drop the first occurrences.
+	seq := Map(Range(0, 2*n, 1), func(i int) int { return i % n })
+	yielded := 0
+	seen := make(map[int]bool, n)
+	for v := range ApproxDistinct(seq, n, fpRate) {
+		if seen[v] {
+			t.Fatalf("ApproxDistinct() yielded %v twice", v)
+		}
+		seen[v] = true
+		yielded++
+	}
+	if dropped := float64(n-yielded) / n; dropped > 2*fpRate {
+		t.Errorf("ApproxDistinct() dropped %.2f%% of distinct elements, want at most %.2f%%", 100*dropped, 200*fpRate)
+	}
+}
+
+func TestApproxDistinctPanics(t *testing.T) {
+	for _, rate := range []float64{0, 1, math.NaN()} {
+		if panicked, msg := panics(func() { ApproxDistinct(FromElements(1), 10, rate) }); !panicked || msg != fpRatePanicMessage {
+			t.Errorf("ApproxDistinct(fpRate=%v) panicked = %v with %q, want %q", rate, panicked, msg, fpRatePanicMessage)
+		}
+	}
+}
+
+func TestHeavyHitters(t *testing.T) {
+	words := FromElements("a", "b", "a", "c", "a", "b")
+	keys, values := unwrapIterator2(HeavyHitters(words, 2), 5)
+	if want := []string{"a", "b"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("HeavyHitters() keys = %v, want %v", keys, want)
+	}
+	if want := []int{3, 2}; !reflect.DeepEqual(values, want) {
+		t.Errorf("HeavyHitters() values = %v, want %v", values, want)
+	}
+
+	keys, _ = unwrapIterator2(HeavyHitters(words, 0), 5)
+	if len(keys) != 0 {
+		t.Errorf("HeavyHitters() = %v, want none", keys)
+	}
+}
+
+func TestHeavyHittersZipf(t *testing.T) {
+	const n, k = 200_000, 5
+	zipf := rand.NewZipf(rand.New(rand.NewPCG(1, 2)), 1.2, 1, 100_000)
+	data := make([]uint64, n)
+	counts := map[uint64]int{}
+	for i := range data {
+		data[i] = zipf.Uint64()
+		counts[data[i]]++
+	}
+
+	// The Zipf distribution ranks 0 as the most frequent value, then 1, and so on.
+	bound := int(math.Ceil(math.E * n / sketchMinWidth))
+	var got []uint64
+	for v, est := range HeavyHitters(slices.Values(data), k) {
+		got = append(got, v)
+		if est < counts[v] || est > counts[v]+bound {
+			t.Errorf("HeavyHitters() estimated %v for %v, want between %v and %v", est, v, counts[v], counts[v]+bound)
+		}
+	}
+	if want := []uint64{0, 1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HeavyHitters() = %v, want %v", got, want)
+	}
+}
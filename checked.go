@@ -0,0 +1,95 @@
+package itertools
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"runtime"
+	"sync/atomic"
+)
+
+// contract tracks the calls a sequence makes to its yield function
+// and panics as soon as one of them breaks the range-over-func contract.
+type contract struct {
+	source   string
+	inYield  atomic.Bool
+	stopped  atomic.Bool
+	finished atomic.Bool
+}
+
+func newContract(seq any) *contract {
+	source := "unknown sequence"
+	if fn := runtime.FuncForPC(reflect.ValueOf(seq).Pointer()); fn != nil {
+		file, line := fn.FileLine(fn.Entry())
+		source = fmt.Sprintf("%s (%s:%d)", fn.Name(), file, line)
+	}
+	return &contract{source: source}
+}
+
+// violation panics with a message describing the broken rule and where yield was called from.
+func (c *contract) violation(rule string) {
+	caller := "unknown location"
+	if _, file, line, ok := runtime.Caller(3); ok {
+		caller = fmt.Sprintf("%s:%d", file, line)
+	}
+	panic(fmt.Sprintf("itertools: sequence %s %s, at %s", c.source, rule, caller))
+}
+
+// call calls `yield` on behalf of the sequence, checking the contract before and after.
+func (c *contract) call(yield func() bool) bool {
+	if c.finished.Load() {
+		c.violation("called yield after the range loop exited")
+	}
+	if c.stopped.Load() {
+		c.violation("called yield again after it returned false")
+	}
+	if !c.inYield.CompareAndSwap(false, true) {
+		c.violation("called yield concurrently")
+	}
+	ok := yield()
+	c.inYield.Store(false)
+	if !ok {
+		c.stopped.Store(true)
+	}
+	return ok
+}
+
+// Checked wraps a sequence with checks for the range-over-func contract.
+// It panics with a message pointing to the offending sequence if the sequence
+// calls yield again after it returned false, calls yield concurrently
+// or calls yield after the range loop over it has exited.
+//
+// It is meant for tests and debugging of hand-written sequences.
+// Building with the `itertools_checked` tag wraps the sequences
+// returned by every operator of this package with the same checks.
+//
+// Example:
+//
+//	bad := func(yield func(int) bool) {
+//		yield(1)
+//		yield(2) // ignores the result of the previous call
+//	}
+//	for v := range Checked(bad) {
+//		break // panics, since bad calls yield after it returned false
+//	}
+func Checked[V any](seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		c := newContract(seq)
+		defer c.finished.Store(true)
+		seq(func(v V) bool {
+			return c.call(func() bool { return yield(v) })
+		})
+	}
+}
+
+// Checked2 wraps a key-value sequence with checks for the range-over-func contract.
+// See Checked for details.
+func Checked2[K, V any](seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		c := newContract(seq)
+		defer c.finished.Store(true)
+		seq(func(k K, v V) bool {
+			return c.call(func() bool { return yield(k, v) })
+		})
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !itertools_checked
+
+package itertools
+
+import (
+	"iter"
+)
+
+// checked returns the sequences returned by the operators of this package as is.
+// Build with the itertools_checked tag to wrap them with contract checks.
+func checked[V any](seq iter.Seq[V]) iter.Seq[V] {
+	return seq
+}
+
+func checked2[K, V any](seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return seq
+}
@@ -0,0 +1,17 @@
+//go:build itertools_checked
+
+package itertools
+
+import (
+	"iter"
+)
+
+// checked wraps the sequences returned by the operators of this package
+// with contract checks, since the itertools_checked build tag is set.
+func checked[V any](seq iter.Seq[V]) iter.Seq[V] {
+	return Checked(seq)
+}
+
+func checked2[K, V any](seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return Checked2(seq)
+}
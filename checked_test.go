@@ -0,0 +1,127 @@
+package itertools
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	seq := Checked(FromElements(1, 2, 3))
+	if got, want := slices.Collect(seq), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Checked() = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(Take(seq, 2)), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Checked() = %v, want %v", got, want)
+	}
+}
+
+func ignoresStop(yield func(int) bool) {
+	yield(1)
+	yield(2)
+}
+
+func TestCheckedViolations(t *testing.T) {
+	var leaked func(int) bool
+	inside, release := make(chan struct{}), make(chan struct{})
+	type testCase struct {
+		name     string
+		seq      iter.Seq[int]
+		consume  func(iter.Seq[int])
+		wantRule string
+	}
+	tests := []testCase{
+		{
+			name: "yield after false",
+			seq:  ignoresStop,
+			consume: func(seq iter.Seq[int]) {
+				for range seq {
+					break
+				}
+			},
+			wantRule: "called yield again after it returned false",
+		},
+		{
+			name: "yield after exit",
+			seq: func(yield func(int) bool) {
+				leaked = yield
+			},
+			consume: func(seq iter.Seq[int]) {
+				for range seq {
+				}
+				leaked(1)
+			},
+			wantRule: "called yield after the range loop exited",
+		},
+		{
+			name: "concurrent yield",
+			seq: func(yield func(int) bool) {
+				go yield(1)
+				<-inside
+				defer close(release)
+				yield(2)
+			},
+			consume: func(seq iter.Seq[int]) {
+				seq(func(v int) bool {
+					if v == 1 {
+						close(inside)
+						<-release
+					}
+					return true
+				})
+			},
+			wantRule: "called yield concurrently",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tt.wantRule) {
+					t.Errorf("Checked() panicked with %q, want %q", msg, tt.wantRule)
+				}
+			}()
+			tt.consume(Checked(tt.seq))
+		})
+	}
+}
+
+func TestCheckedReportsSource(t *testing.T) {
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "ignoresStop") || !strings.Contains(msg, "checked_test.go") {
+			t.Errorf("Checked() panic does not point to the offending sequence: %q", msg)
+		}
+	}()
+	for range Checked(ignoresStop) {
+		break
+	}
+}
+
+func TestChecked2(t *testing.T) {
+	seq := Checked2(Enumerate(FromElements("a", "b", "c")))
+	keys, values := unwrapIterator2(seq, 2)
+	if want := []int{0, 1}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Checked2() keys = %v, want %v", keys, want)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Checked2() values = %v, want %v", values, want)
+	}
+
+	bad := func(yield func(int, int) bool) {
+		yield(1, 1)
+		yield(2, 2)
+	}
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "called yield again after it returned false") {
+			t.Errorf("Checked2() panicked with %q", msg)
+		}
+	}()
+	for range Checked2(bad) {
+		break
+	}
+}
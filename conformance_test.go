@@ -0,0 +1,128 @@
+package itertools
+
+import (
+	"cmp"
+	"iter"
+	"testing"
+
+	"github.com/dzherb/go-itertools/itertest"
+)
+
+func TestConformance(t *testing.T) {
+	type testCase struct {
+		name    string
+		factory func() iter.Seq[int]
+		want    []int
+	}
+	tests := []testCase{
+		{
+			name:    "FromElements",
+			factory: func() iter.Seq[int] { return FromElements(1, 2, 3) },
+			want:    []int{1, 2, 3},
+		},
+		{
+			name:    "Repeat",
+			factory: func() iter.Seq[int] { return Repeat(7, 3) },
+			want:    []int{7, 7, 7},
+		},
+		{
+			name:    "RepeatSeq",
+			factory: func() iter.Seq[int] { return RepeatSeq(FromElements(1, 2), 2) },
+			want:    []int{1, 2, 1, 2},
+		},
+		{
+			name:    "Chain",
+			factory: func() iter.Seq[int] { return Chain(FromElements(1, 2), FromElements(3)) },
+			want:    []int{1, 2, 3},
+		},
+		{
+			name:    "Slice",
+			factory: func() iter.Seq[int] { return Slice(Count(0, 1), 1, 7, 2) },
+			want:    []int{1, 3, 5},
+		},
+		{
+			name: "TakeWhile",
+			factory: func() iter.Seq[int] {
+				return TakeWhile(Count(0, 1), func(v int) bool { return v < 3 })
+			},
+			want: []int{0, 1, 2},
+		},
+		{
+			name: "DropWhile",
+			factory: func() iter.Seq[int] {
+				return DropWhile(FromElements(0, 1, 2, 3), func(v int) bool { return v < 2 })
+			},
+			want: []int{2, 3},
+		},
+		{
+			name: "Filter",
+			factory: func() iter.Seq[int] {
+				return Filter(FromElements(1, 2, 3, 4), func(v int) bool { return v%2 == 0 })
+			},
+			want: []int{2, 4},
+		},
+		{
+			name: "Map",
+			factory: func() iter.Seq[int] {
+				return Map(FromElements(1, 2, 3), func(v int) int { return v * 10 })
+			},
+			want: []int{10, 20, 30},
+		},
+		{
+			name: "Accumulate",
+			factory: func() iter.Seq[int] {
+				return Accumulate(FromElements(1, 2, 3), 0, func(acc, v int) int { return acc + v })
+			},
+			want: []int{1, 3, 6},
+		},
+		{
+			name: "MergeSorted",
+			factory: func() iter.Seq[int] {
+				return MergeSorted(cmp.Compare[int], FromElements(1, 4), FromElements(2, 3, 5))
+			},
+			want: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "Union",
+			factory: func() iter.Seq[int] {
+				return Union(cmp.Compare[int], FromElements(1, 3, 4), FromElements(2, 3))
+			},
+			want: []int{1, 2, 3, 4},
+		},
+		{
+			name:    "Distinct",
+			factory: func() iter.Seq[int] { return Distinct(FromElements(1, 2, 1, 3, 2)) },
+			want:    []int{1, 2, 3},
+		},
+		{
+			name:    "Compact",
+			factory: func() iter.Seq[int] { return Compact(FromElements(1, 1, 2, 1)) },
+			want:    []int{1, 2, 1},
+		},
+		{
+			name:    "DistinctWindow",
+			factory: func() iter.Seq[int] { return DistinctWindow(FromElements(1, 2, 1, 3, 4, 1), 2) },
+			want:    []int{1, 2, 3, 4, 1},
+		},
+		{
+			name:    "ApproxDistinct",
+			factory: func() iter.Seq[int] { return ApproxDistinct(FromElements(1, 2, 1, 3, 2), 10, 0.01) },
+			want:    []int{1, 2, 3},
+		},
+		{
+			name:    "Zip",
+			factory: func() iter.Seq[int] { return Keys(Zip(Count(0, 1), FromElements("a", "b"))) },
+			want:    []int{0, 1},
+		},
+		{
+			name:    "Cycle",
+			factory: func() iter.Seq[int] { return Take(Cycle(FromElements(1, 2)), 5) },
+			want:    []int{1, 2, 1, 2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itertest.Conformance(t, tt.factory, tt.want)
+		})
+	}
+}
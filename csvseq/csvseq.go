@@ -0,0 +1,289 @@
+// Package csvseq provides lazy sequences over CSV data
+// built on top of the encoding/csv package.
+//
+// Every reading function returns an iter.Seq2 pairing each value with an error.
+// Iteration stops after the first error is yielded. The sequences can be turned
+// into plain ones with itertools.UntilError or stream.FromResults.
+package csvseq
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Error reports a failure to decode a field of a CSV record.
+type Error struct {
+	Line   int    // Line where the field starts
+	Column string // Header of the field
+	Err    error  // The underlying error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("csvseq: line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Records returns a sequence of the records read from `r`.
+// Records are read one at a time, so the input is never loaded as a whole.
+// Parse errors are reported as *csv.ParseError, which carries the line number.
+//
+// Example:
+//
+//	for rec, err := range Records(strings.NewReader("a,b\nc,d\n")) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(rec) // [a b], [c d]
+//	}
+func Records(r io.Reader) iter.Seq2[[]string, error] {
+	return ReaderRecords(csv.NewReader(r))
+}
+
+// ReaderRecords is like Records, but reads from a configured *csv.Reader.
+func ReaderRecords(cr *csv.Reader) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		for {
+			rec, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(rec, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Maps returns a sequence of the records read from `r`, keyed by the header.
+// The first record of the input is treated as the header.
+//
+// Example:
+//
+//	for m, err := range Maps(strings.NewReader("name,age\nBob,42\n")) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(m["name"], m["age"]) // Bob 42
+//	}
+func Maps(r io.Reader) iter.Seq2[map[string]string, error] {
+	return func(yield func(map[string]string, error) bool) {
+		cr := csv.NewReader(r)
+		header, ok := readHeader(cr, yield)
+		if !ok {
+			return
+		}
+		for rec, err := range ReaderRecords(cr) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			m := make(map[string]string, len(header))
+			for i, name := range header {
+				m[name] = rec[i]
+			}
+			if !yield(m, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Decode returns a sequence of structs of type T decoded from the records read from `r`.
+// The first record of the input is treated as the header.
+//
+// Header names are matched against the `csv` tags of the fields of T,
+// falling back to a case-insensitive match of the field names.
+// Fields tagged with `csv:"-"` are ignored, as are columns with no matching field.
+// Fields promoted from embedded struct pointers are matched too,
+// allocating the embedded structs when a record sets them.
+// Supported field types are strings, booleans, integers, floats
+// and types implementing encoding.TextUnmarshaler.
+//
+// It panics if T is not a struct type.
+//
+// Example:
+//
+//	type Person struct {
+//		Name string `csv:"name"`
+//		Age  int    `csv:"age"`
+//	}
+//	for p, err := range Decode[Person](strings.NewReader("name,age\nBob,42\n")) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(p.Name, p.Age) // Bob 42
+//	}
+func Decode[T any](r io.Reader) iter.Seq2[T, error] {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		panic("csvseq: Decode requires a struct type, got " + typ.String())
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		cr := csv.NewReader(r)
+		header, ok := readHeader(cr, func(_ map[string]string, err error) bool {
+			return yield(zero, err)
+		})
+		if !ok {
+			return
+		}
+
+		fields := fieldIndexes(typ, header)
+		for rec, err := range ReaderRecords(cr) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			var v T
+			rv := reflect.ValueOf(&v).Elem()
+			for col, idx := range fields {
+				if idx == nil {
+					continue
+				}
+				field, err := fieldByIndex(rv, idx)
+				if err == nil {
+					err = setField(field, rec[col])
+				}
+				if err != nil {
+					line, _ := cr.FieldPos(col)
+					yield(zero, &Error{Line: line, Column: header[col], Err: err})
+					return
+				}
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Write writes every record of `iter` to `w` in CSV format.
+// Iteration stops at the first write error, which is returned.
+func Write(w io.Writer, iter iter.Seq[[]string]) error {
+	cw := csv.NewWriter(w)
+	for rec := range iter {
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readHeader reads the first record of `cr`, reporting an error through `yield`.
+// An empty input is reported as a missing header.
+func readHeader(cr *csv.Reader, yield func(map[string]string, error) bool) ([]string, bool) {
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, false
+	}
+	if err != nil {
+		yield(nil, err)
+		return nil, false
+	}
+	return header, true
+}
+
+// fieldIndexes maps every column of `header` to the index of the matching field of `typ`.
+// Columns with no matching field are mapped to nil.
+func fieldIndexes(typ reflect.Type, header []string) [][]int {
+	byName := make(map[string][]int)
+	byFold := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+		if tag != "" {
+			byName[tag] = f.Index
+			continue
+		}
+		byFold[strings.ToLower(f.Name)] = f.Index
+	}
+
+	indexes := make([][]int, len(header))
+	for i, name := range header {
+		if idx, ok := byName[name]; ok {
+			indexes[i] = idx
+		} else {
+			indexes[i] = byFold[strings.ToLower(name)]
+		}
+	}
+	return indexes
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but allocates the nil embedded struct pointers
+// on the path to the field. It fails if such a pointer can't be set because its type is unexported.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+func setField(field reflect.Value, s string) error {
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(v)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
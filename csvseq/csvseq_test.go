@@ -0,0 +1,215 @@
+package csvseq
+
+import (
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dzherb/go-itertools/stream"
+)
+
+func TestRecords(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  [][]string
+	}
+	tests := []testCase{
+		{
+			name:  "simple",
+			input: "a,b\nc,d\n",
+			want:  [][]string{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			name:  "quoted",
+			input: "\"a,b\",c\n",
+			want:  [][]string{{"a,b", "c"}},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for rec, err := range Records(strings.NewReader(tt.input)) {
+				if err != nil {
+					t.Fatalf("Records() error = %v", err)
+				}
+				got = append(got, rec)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Records() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordsParseError(t *testing.T) {
+	input := "a,b\nc,d\ne\n"
+	var gotErr error
+	n := 0
+	for _, err := range Records(strings.NewReader(input)) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		n++
+	}
+
+	var parseErr *csv.ParseError
+	if !errors.As(gotErr, &parseErr) {
+		t.Fatalf("Records() error = %v, want *csv.ParseError", gotErr)
+	}
+	if parseErr.Line != 3 {
+		t.Errorf("Records() error line = %d, want 3", parseErr.Line)
+	}
+	if n != 2 {
+		t.Errorf("Records() yielded %d records before the error, want 2", n)
+	}
+}
+
+func TestMaps(t *testing.T) {
+	input := "name,age\nBob,42\nAlice,37\n"
+	var got []map[string]string
+	for m, err := range Maps(strings.NewReader(input)) {
+		if err != nil {
+			t.Fatalf("Maps() error = %v", err)
+		}
+		got = append(got, m)
+	}
+	want := []map[string]string{
+		{"name": "Bob", "age": "42"},
+		{"name": "Alice", "age": "37"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Maps() = %v, want %v", got, want)
+	}
+}
+
+type person struct {
+	Name    string  `csv:"name"`
+	Age     int     `csv:"age"`
+	Score   float64 // matched case-insensitively
+	Ignored string  `csv:"-"`
+}
+
+func TestDecode(t *testing.T) {
+	input := "name,age,score,ignored,extra\nBob,42,1.5,x,y\nAlice,37,2,x,y\n"
+	var got []person
+	for p, err := range Decode[person](strings.NewReader(input)) {
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		got = append(got, p)
+	}
+	want := []person{
+		{Name: "Bob", Age: 42, Score: 1.5},
+		{Name: "Alice", Age: 37, Score: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	input := "name,age\nBob,42\nAlice,old\n"
+	var gotErr error
+	for _, err := range Decode[person](strings.NewReader(input)) {
+		gotErr = err
+	}
+
+	var decodeErr *Error
+	if !errors.As(gotErr, &decodeErr) {
+		t.Fatalf("Decode() error = %v, want *Error", gotErr)
+	}
+	if decodeErr.Line != 3 || decodeErr.Column != "age" {
+		t.Errorf("Decode() error = %v, want line 3, column \"age\"", decodeErr)
+	}
+}
+
+type Inner struct {
+	X int `csv:"x"`
+}
+
+type inner struct {
+	Z int `csv:"z"`
+}
+
+func TestDecodeEmbeddedPointer(t *testing.T) {
+	type outer struct {
+		*Inner
+		Y int `csv:"y"`
+	}
+	var got []outer
+	for v, err := range Decode[outer](strings.NewReader("x,y\n1,2\n3,4\n")) {
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		got = append(got, v)
+	}
+	want := []outer{{&Inner{1}, 2}, {&Inner{3}, 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %v, want %v", got, want)
+	}
+
+	type unexported struct {
+		*inner
+		Y int `csv:"y"`
+	}
+	var gotErr error
+	for _, err := range Decode[unexported](strings.NewReader("z,y\n1,2\n")) {
+		gotErr = err
+	}
+	var decodeErr *Error
+	if !errors.As(gotErr, &decodeErr) || decodeErr.Column != "z" {
+		t.Errorf("Decode() error = %v, want *Error for column \"z\"", gotErr)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	records := [][]string{{"a", "b"}, {"c,d", "e"}}
+	var sb strings.Builder
+	if err := Write(&sb, slices.Values(records)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := "a,b\n\"c,d\",e\n"
+	if got := sb.String(); got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+
+	var got [][]string
+	for rec, err := range Records(strings.NewReader(sb.String())) {
+		if err != nil {
+			t.Fatalf("Records() error = %v", err)
+		}
+		got = append(got, rec)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Errorf("Write() round trip = %v, want %v", got, records)
+	}
+}
+
+func TestStream(t *testing.T) {
+	input := "name,age\nBob,42\nAlice,37\nEve,19\n"
+	var err error
+	got := stream.Map(
+		stream.FromResults(Decode[person](strings.NewReader(input)), &err).
+			Filter(func(p person) bool { return p.Age > 20 }),
+		func(p person) string { return p.Name },
+	).Collect()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	want := []string{"Bob", "Alice"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() stream = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,182 @@
+package itertools
+
+import (
+	"container/list"
+	"iter"
+)
+
+// Distinct returns a sequence of the elements of `iter` with duplicates removed,
+// keeping the first occurrence of each element.
+// Every distinct element is remembered, so memory grows with their number;
+// see DistinctWindow and ApproxDistinct for bounded alternatives.
+//
+// Example:
+//
+//	for v := range Distinct(FromElements(3, 1, 3, 2, 1)) {
+//		fmt.Println(v) // 3, 1, 2
+//	}
+func Distinct[V comparable](iter iter.Seq[V]) iter.Seq[V] {
+	return DistinctBy(iter, func(v V) V { return v })
+}
+
+// DistinctBy returns a sequence of the elements of `iter` with duplicates removed,
+// where two elements are duplicates if `key` returns the same value for them.
+// The first element with each key is kept.
+//
+// Example:
+//
+//	words := FromElements("apple", "avocado", "banana", "blueberry", "cherry")
+//	byLetter := DistinctBy(words, func(s string) byte { return s[0] })
+//	for v := range byLetter {
+//		fmt.Println(v) // "apple", "banana", "cherry"
+//	}
+func DistinctBy[V any, K comparable](iter iter.Seq[V], key func(V) K) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		seen := make(map[K]struct{})
+		for v := range iter {
+			k := key(v)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Distinct2 returns a sequence of the key-value pairs of `iter` with duplicate keys removed,
+// keeping the first pair with each key.
+//
+// Example:
+//
+//	seq := Zip(FromElements("a", "b", "a"), FromElements(1, 2, 3))
+//	for k, v := range Distinct2(seq) {
+//		fmt.Println(k, v) // "a" 1, "b" 2
+//	}
+func Distinct2[K comparable, V any](iter iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return checked2(func(yield func(K, V) bool) {
+		seen := make(map[K]struct{})
+		for k, v := range iter {
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(k, v) {
+				return
+			}
+		}
+	})
+}
+
+// Compact returns a sequence of the elements of `iter` where runs of equal
+// consecutive elements are replaced by a single one, like the uniq command does.
+// It keeps no more than one element in memory, so it works on infinite sequences.
+//
+// Example:
+//
+//	for v := range Compact(FromElements(1, 1, 2, 2, 2, 1)) {
+//		fmt.Println(v) // 1, 2, 1
+//	}
+func Compact[V comparable](iter iter.Seq[V]) iter.Seq[V] {
+	return CompactFunc(iter, func(a, b V) bool { return a == b })
+}
+
+// CompactFunc is like Compact, but uses `eq` to compare elements.
+// Of every run of equal elements, the first one is kept.
+//
+// Example:
+//
+//	seq := FromElements("a", "A", "b", "B", "a")
+//	for v := range CompactFunc(seq, strings.EqualFold) {
+//		fmt.Println(v) // "a", "b", "a"
+//	}
+func CompactFunc[V any](iter iter.Seq[V], eq func(V, V) bool) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		var prev V
+		first := true
+		for v := range iter {
+			if !first && eq(prev, v) {
+				continue
+			}
+			first = false
+			prev = v
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// DistinctConsecutive is an alias for Compact.
+func DistinctConsecutive[V comparable](iter iter.Seq[V]) iter.Seq[V] {
+	return Compact(iter)
+}
+
+// DistinctWindow returns a sequence of the elements of `iter` with duplicates removed
+// within a window of the `size` most recently seen distinct elements.
+// An element is dropped if it is among them; seeing it again makes it the most recent.
+// Once more than `size` distinct elements are seen, the least recently seen one
+// is forgotten, so it is yielded again the next time it occurs.
+// Memory is bounded by `size`, which makes it suitable for infinite sequences.
+//
+// It panics if `size` is negative.
+//
+// Example:
+//
+//	seq := FromElements(1, 2, 1, 3, 4, 1, 2)
+//	for v := range DistinctWindow(seq, 2) {
+//		fmt.Println(v) // 1, 2, 3, 4, 1, 2
+//	}
+func DistinctWindow[V comparable](iter iter.Seq[V], size int) iter.Seq[V] {
+	if size < 0 {
+		panic(countPanicMessage)
+	}
+
+	return checked(func(yield func(V) bool) {
+		recent := newLRU[V](size)
+		for v := range iter {
+			if recent.touch(v) {
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// lru is a set remembering up to `size` of the most recently used elements.
+type lru[V comparable] struct {
+	size  int
+	order *list.List // Front is the most recent
+	elems map[V]*list.Element
+}
+
+func newLRU[V comparable](size int) *lru[V] {
+	return &lru[V]{
+		size:  size,
+		order: list.New(),
+		elems: make(map[V]*list.Element, size),
+	}
+}
+
+// touch marks `v` as the most recently used element
+// and reports whether it was already in the set.
+func (l *lru[V]) touch(v V) bool {
+	if e, ok := l.elems[v]; ok {
+		l.order.MoveToFront(e)
+		return true
+	}
+	if l.size == 0 {
+		return false
+	}
+	if l.order.Len() == l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.elems, oldest.Value.(V))
+	}
+	l.elems[v] = l.order.PushFront(v)
+	return false
+}
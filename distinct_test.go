@@ -0,0 +1,156 @@
+package itertools
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDistinct(t *testing.T) {
+	type testCase struct {
+		name  string
+		input []int
+		want  []int
+	}
+	tests := []testCase{
+		{
+			name:  "with duplicates",
+			input: []int{3, 1, 3, 2, 1},
+			want:  []int{3, 1, 2},
+		},
+		{
+			name:  "all equal",
+			input: []int{7, 7, 7},
+			want:  []int{7},
+		},
+		{
+			name:  "empty",
+			input: nil,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Distinct(slices.Values(tt.input))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Distinct() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistinctBy(t *testing.T) {
+	words := FromElements("apple", "avocado", "banana", "blueberry", "cherry")
+	got := slices.Collect(DistinctBy(words, func(s string) byte { return s[0] }))
+	if want := []string{"apple", "banana", "cherry"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DistinctBy() = %v, want %v", got, want)
+	}
+}
+
+func TestDistinct2(t *testing.T) {
+	seq := Zip(FromElements("a", "b", "a", "c", "b"), FromElements(1, 2, 3, 4, 5))
+	keys, values := unwrapIterator2(Distinct2(seq), 10)
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Distinct2() keys = %v, want %v", keys, want)
+	}
+	if want := []int{1, 2, 4}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Distinct2() values = %v, want %v", values, want)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	type testCase struct {
+		name  string
+		input []int
+		want  []int
+	}
+	tests := []testCase{
+		{
+			name:  "runs",
+			input: []int{1, 1, 2, 2, 2, 1},
+			want:  []int{1, 2, 1},
+		},
+		{
+			name:  "leading zero values",
+			input: []int{0, 0, 1},
+			want:  []int{0, 1},
+		},
+		{
+			name:  "empty",
+			input: nil,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Compact(slices.Values(tt.input))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compact() = %v, want %v", got, tt.want)
+			}
+			if got := slices.Collect(DistinctConsecutive(slices.Values(tt.input))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DistinctConsecutive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompactFunc(t *testing.T) {
+	seq := FromElements("a", "A", "b", "B", "a")
+	if got, want := slices.Collect(CompactFunc(seq, strings.EqualFold)), []string{"a", "b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CompactFunc() = %v, want %v", got, want)
+	}
+}
+
+func TestDistinctWindow(t *testing.T) {
+	type testCase struct {
+		name  string
+		input []int
+		size  int
+		want  []int
+	}
+	tests := []testCase{
+		{
+			name:  "window of 2",
+			input: []int{1, 2, 1, 3, 4, 1, 2},
+			size:  2,
+			want:  []int{1, 2, 3, 4, 1, 2},
+		},
+		{
+			name:  "window larger than input",
+			input: []int{1, 2, 1, 3, 2},
+			size:  10,
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "zero window",
+			input: []int{1, 1, 2},
+			size:  0,
+			want:  []int{1, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(DistinctWindow(slices.Values(tt.input), tt.size)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DistinctWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistinctWindowOnCycle(t *testing.T) {
+	// A window as large as the cycle drops every repetition,
+	// so only a bounded prefix is needed to see all elements.
+	seq := DistinctWindow(Cycle(FromElements(1, 2, 3)), 3)
+	if got, want := slices.Collect(Take(seq, 3)), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DistinctWindow() = %v, want %v", got, want)
+	}
+
+	// A smaller window forgets elements before they come back.
+	seq = DistinctWindow(Cycle(FromElements(1, 2, 3)), 2)
+	if got, want := slices.Collect(Take(seq, 5)), []int{1, 2, 3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DistinctWindow() = %v, want %v", got, want)
+	}
+
+	if panicked, msg := panics(func() { DistinctWindow(FromElements(1), -1) }); !panicked || msg != countPanicMessage {
+		t.Errorf("DistinctWindow(-1) panicked = %v with %q, want %q", panicked, msg, countPanicMessage)
+	}
+}
@@ -0,0 +1,225 @@
+// Package fsseq provides lazy sequences over the entries of an fs.FS.
+package fsseq
+
+import (
+	"io/fs"
+	"iter"
+	"path"
+)
+
+// Option configures a walk.
+type Option func(*config)
+
+type config struct {
+	maxDepth     int
+	pattern      string
+	exclude      func(string, fs.DirEntry) bool
+	breadthFirst bool
+}
+
+// MaxDepth limits the walk to entries at most `n` levels below the root.
+// The root itself is at depth 0.
+func MaxDepth(n int) Option {
+	return func(c *config) {
+		c.maxDepth = n
+	}
+}
+
+// Glob limits the yielded entries to those whose base name matches `pattern`,
+// using the syntax of path.Match. Directories that don't match are still walked,
+// but since they are not yielded, Control.SkipDir can't target them: use Exclude instead.
+func Glob(pattern string) Option {
+	return func(c *config) {
+		c.pattern = pattern
+	}
+}
+
+// Exclude skips the entries for which `fn` returns true, given their path,
+// along with the contents of such directories. It is checked before Glob,
+// so it can skip directories that don't match the pattern.
+func Exclude(fn func(path string, d fs.DirEntry) bool) Option {
+	return func(c *config) {
+		c.exclude = fn
+	}
+}
+
+// BreadthFirst makes the walk visit all entries of a directory level
+// before descending to the next one. By default the walk is depth-first.
+func BreadthFirst() Option {
+	return func(c *config) {
+		c.breadthFirst = true
+	}
+}
+
+// Control controls a walk while it is in progress and reports its outcome.
+type Control struct {
+	skip bool
+	err  error
+}
+
+// SkipDir skips the entry yielded last. If it is a directory,
+// its contents are not visited. Otherwise, the remaining entries
+// of its parent directory are skipped.
+//
+// It is the counterpart of returning fs.SkipDir from an fs.WalkDirFunc.
+func (c *Control) SkipDir() {
+	c.skip = true
+}
+
+// Err returns the error that stopped the last walk, if any.
+func (c *Control) Err() error {
+	return c.err
+}
+
+func (c *Control) takeSkip() bool {
+	skip := c.skip
+	c.skip = false
+	return skip
+}
+
+// Walk returns a sequence of (path, entry) pairs for the file tree rooted at `root`,
+// including `root` itself. Entries within a directory are visited in lexical order.
+//
+// The returned Control allows skipping directories while iterating
+// and reports the first error encountered, which stops the walk.
+//
+// Example:
+//
+//	vendor := func(_ string, d fs.DirEntry) bool { return d.IsDir() && d.Name() == "vendor" }
+//	seq, ctl := Walk(os.DirFS("."), ".", Glob("*.go"), Exclude(vendor))
+//	for p := range seq {
+//		fmt.Println(p)
+//	}
+//	if err := ctl.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+func Walk(fsys fs.FS, root string, opts ...Option) (iter.Seq2[string, fs.DirEntry], *Control) {
+	cfg := config{maxDepth: -1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	ctl := &Control{}
+
+	seq := func(yield func(string, fs.DirEntry) bool) {
+		ctl.skip, ctl.err = false, nil
+		w := &walker{fsys: fsys, cfg: cfg, ctl: ctl, yield: yield}
+
+		info, err := fs.Stat(fsys, root)
+		if err != nil {
+			ctl.err = err
+			return
+		}
+		d := fs.FileInfoToDirEntry(info)
+		skip, ok := w.visit(root, d)
+		if !ok || skip || !d.IsDir() || !w.descend(0) {
+			return
+		}
+
+		if cfg.breadthFirst {
+			w.walkBreadthFirst(root)
+		} else {
+			w.walkDepthFirst(root, 0)
+		}
+	}
+	return seq, ctl
+}
+
+type walker struct {
+	fsys  fs.FS
+	cfg   config
+	ctl   *Control
+	yield func(string, fs.DirEntry) bool
+}
+
+// visit yields the entry unless it is excluded or doesn't match the pattern.
+// It reports whether the entry should be skipped, as by Control.SkipDir,
+// and whether the walk should go on.
+func (w *walker) visit(p string, d fs.DirEntry) (skip, ok bool) {
+	if w.cfg.exclude != nil && w.cfg.exclude(p, d) {
+		return d.IsDir(), true
+	}
+	if w.cfg.pattern != "" {
+		matched, err := path.Match(w.cfg.pattern, d.Name())
+		if err != nil {
+			w.ctl.err = err
+			return false, false
+		}
+		if !matched {
+			return false, true
+		}
+	}
+	if !w.yield(p, d) {
+		return false, false
+	}
+	return w.ctl.takeSkip(), true
+}
+
+// descend reports whether the children of a directory at `depth` should be visited.
+func (w *walker) descend(depth int) bool {
+	return w.cfg.maxDepth < 0 || depth < w.cfg.maxDepth
+}
+
+// walkDepthFirst visits the contents of the directory `dir`
+// and reports whether the walk should go on.
+func (w *walker) walkDepthFirst(dir string, depth int) bool {
+	entries, err := fs.ReadDir(w.fsys, dir)
+	if err != nil {
+		w.ctl.err = err
+		return false
+	}
+	for _, d := range entries {
+		p := path.Join(dir, d.Name())
+		skip, ok := w.visit(p, d)
+		if !ok {
+			return false
+		}
+		if !d.IsDir() {
+			if skip {
+				break
+			}
+			continue
+		}
+		if skip || !w.descend(depth+1) {
+			continue
+		}
+		if !w.walkDepthFirst(p, depth+1) {
+			return false
+		}
+	}
+	return true
+}
+
+// walkBreadthFirst visits the contents of the directory `root` level by level.
+func (w *walker) walkBreadthFirst(root string) {
+	type dirAt struct {
+		path  string
+		depth int
+	}
+	queue := []dirAt{{root, 0}}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+
+		entries, err := fs.ReadDir(w.fsys, dir.path)
+		if err != nil {
+			w.ctl.err = err
+			return
+		}
+		for _, d := range entries {
+			p := path.Join(dir.path, d.Name())
+			skip, ok := w.visit(p, d)
+			if !ok {
+				return
+			}
+			if !d.IsDir() {
+				if skip {
+					break
+				}
+				continue
+			}
+			if !skip && w.descend(dir.depth+1) {
+				queue = append(queue, dirAt{p, dir.depth + 1})
+			}
+		}
+	}
+}
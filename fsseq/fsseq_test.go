@@ -0,0 +1,204 @@
+package fsseq
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	it "github.com/dzherb/go-itertools"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"a.go":           {},
+		"b.txt":          {},
+		"dir/c.go":       {},
+		"dir/sub/d.go":   {},
+		"dir/sub/e.txt":  {},
+		"skip/f.go":      {},
+		"skip/deep/g.go": {},
+	}
+}
+
+func TestWalk(t *testing.T) {
+	type testCase struct {
+		name string
+		root string
+		opts []Option
+		want []string
+	}
+	tests := []testCase{
+		{
+			name: "depth first",
+			root: ".",
+			want: []string{
+				".", "a.go", "b.txt", "dir", "dir/c.go", "dir/sub", "dir/sub/d.go",
+				"dir/sub/e.txt", "skip", "skip/deep", "skip/deep/g.go", "skip/f.go",
+			},
+		},
+		{
+			name: "breadth first",
+			root: ".",
+			opts: []Option{BreadthFirst()},
+			want: []string{
+				".", "a.go", "b.txt", "dir", "skip", "dir/c.go", "dir/sub",
+				"skip/deep", "skip/f.go", "dir/sub/d.go", "dir/sub/e.txt", "skip/deep/g.go",
+			},
+		},
+		{
+			name: "max depth",
+			root: ".",
+			opts: []Option{MaxDepth(1)},
+			want: []string{".", "a.go", "b.txt", "dir", "skip"},
+		},
+		{
+			name: "glob",
+			root: ".",
+			opts: []Option{Glob("*.go")},
+			want: []string{"a.go", "dir/c.go", "dir/sub/d.go", "skip/deep/g.go", "skip/f.go"},
+		},
+		{
+			name: "subdirectory",
+			root: "dir",
+			opts: []Option{Glob("*.go"), BreadthFirst()},
+			want: []string{"dir/c.go", "dir/sub/d.go"},
+		},
+		{
+			name: "file root",
+			root: "a.go",
+			want: []string{"a.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq, ctl := Walk(testFS(), tt.root, tt.opts...)
+			got := slices.Collect(it.Keys(seq))
+			if err := ctl.Err(); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Walk() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalkSkipDir(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+		skip string
+		want []string
+	}
+	tests := []testCase{
+		{
+			name: "skip directory",
+			skip: "skip",
+			want: []string{
+				".", "a.go", "b.txt", "dir", "dir/c.go", "dir/sub",
+				"dir/sub/d.go", "dir/sub/e.txt", "skip",
+			},
+		},
+		{
+			name: "skip directory breadth first",
+			opts: []Option{BreadthFirst()},
+			skip: "dir",
+			want: []string{
+				".", "a.go", "b.txt", "dir", "skip", "skip/deep", "skip/f.go", "skip/deep/g.go",
+			},
+		},
+		{
+			name: "skip from a file",
+			skip: "dir/sub/d.go",
+			want: []string{
+				".", "a.go", "b.txt", "dir", "dir/c.go", "dir/sub", "dir/sub/d.go",
+				"skip", "skip/deep", "skip/deep/g.go", "skip/f.go",
+			},
+		},
+		{
+			name: "skip root",
+			skip: ".",
+			want: []string{"."},
+		},
+		{
+			name: "glob skip matching directory",
+			opts: []Option{Glob("s*")},
+			skip: "skip",
+			want: []string{"dir/sub", "skip"},
+		},
+		{
+			name: "glob skip from a file",
+			opts: []Option{Glob("*.go")},
+			skip: "dir/c.go",
+			want: []string{"a.go", "dir/c.go", "skip/deep/g.go", "skip/f.go"},
+		},
+		{
+			name: "glob exclude directory",
+			opts: []Option{Glob("*.go"), Exclude(func(_ string, d fs.DirEntry) bool {
+				return d.IsDir() && d.Name() == "skip"
+			})},
+			skip: "dir/sub/d.go",
+			want: []string{"a.go", "dir/c.go", "dir/sub/d.go"},
+		},
+		{
+			name: "exclude files breadth first",
+			opts: []Option{BreadthFirst(), Exclude(func(p string, d fs.DirEntry) bool {
+				return path.Ext(p) == ".go"
+			})},
+			want: []string{".", "b.txt", "dir", "skip", "dir/sub", "skip/deep", "dir/sub/e.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq, ctl := Walk(testFS(), ".", tt.opts...)
+			var got []string
+			for p := range seq {
+				got = append(got, p)
+				if p == tt.skip {
+					ctl.SkipDir()
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Walk() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalkError(t *testing.T) {
+	seq, ctl := Walk(testFS(), "missing")
+	for p := range seq {
+		t.Errorf("Walk() yielded %q for a missing root", p)
+	}
+	if err := ctl.Err(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Walk() error = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestWalkBreak(t *testing.T) {
+	seq, ctl := Walk(testFS(), ".")
+	got := slices.Collect(it.Keys(it.Take2(seq, 3)))
+	want := []string{".", "a.go", "b.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Walk() = %v, want %v", got, want)
+	}
+	if err := ctl.Err(); err != nil {
+		t.Errorf("Walk() error = %v", err)
+	}
+}
+
+func TestWalkWithOperators(t *testing.T) {
+	seq, _ := Walk(testFS(), ".")
+	files := it.Filter(it.Keys(seq), func(p string) bool {
+		return path.Ext(p) == ".txt"
+	})
+	got := slices.Collect(files)
+	want := []string{"b.txt", "dir/sub/e.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Walk() = %v, want %v", got, want)
+	}
+}
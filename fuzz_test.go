@@ -0,0 +1,181 @@
+package itertools
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/dzherb/go-itertools/itertest"
+)
+
+// The fuzz targets below compare the operators with straightforward
+// slice-based reference implementations. Their seed corpora
+// run as regular tests, covering the edge cases found so far.
+
+// earlyTerminationLimit bounds the number of early stops checked per input,
+// since every check iterates the sequence again.
+const earlyTerminationLimit = 16
+
+func ints(data []byte) []int {
+	out := make([]int, len(data))
+	for i, b := range data {
+		out[i] = int(b)
+	}
+	return out
+}
+
+// panics reports whether `f` panics, along with the panic message.
+func panics(f func()) (panicked bool, msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked, msg = true, fmt.Sprint(r)
+		}
+	}()
+	f()
+	return false, ""
+}
+
+// refSlice slices `s` the way Python does.
+func refSlice(s []int, start, stop, step int) []int {
+	resolve := func(i int) int {
+		if i < 0 {
+			return max(len(s)+i, 0)
+		}
+		return min(i, len(s))
+	}
+	var out []int
+	for i := resolve(start); i < resolve(stop); i += step {
+		out = append(out, s[i])
+	}
+	return out
+}
+
+func FuzzSlice(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4, 5}, int8(0), int8(5), int8(1), false)
+	f.Add([]byte{1, 2, 3, 4, 5}, int8(1), int8(4), int8(2), false)
+	f.Add([]byte{1, 2, 3}, int8(2), int8(1), int8(1), false)
+	f.Add([]byte{1, 2, 3}, int8(0), int8(10), int8(0), false)
+	f.Add([]byte{1, 2, 3}, int8(0), int8(10), int8(-1), false)
+	f.Add([]byte{1, 2, 3, 4, 5}, int8(-2), int8(0), int8(1), true)
+	f.Add([]byte{1, 2, 3, 4, 5}, int8(1), int8(-1), int8(2), false)
+	f.Add([]byte{1, 2, 3, 4, 5}, int8(-4), int8(-1), int8(2), false)
+	f.Add([]byte{1, 2}, int8(-5), int8(-4), int8(1), false)
+	f.Add([]byte{}, int8(0), int8(0), int8(1), false)
+	f.Fuzz(func(t *testing.T, data []byte, start, stop, step int8, open bool) {
+		s := ints(data)
+		stopAt := int(stop)
+		if open {
+			stopAt = End
+		}
+		if step <= 0 {
+			panicked, msg := panics(func() { Slice(slices.Values(s), int(start), stopAt, int(step)) })
+			if !panicked || msg != slicePanicMessage {
+				t.Fatalf("Slice(step=%d) panicked = %v with %q, want %q", step, panicked, msg, slicePanicMessage)
+			}
+			return
+		}
+
+		seq := Slice(slices.Values(s), int(start), stopAt, int(step))
+		want := refSlice(s, int(start), stopAt, int(step))
+		itertest.AssertSeqEqual(t, seq, want)
+		itertest.CheckEarlyTermination(t, seq, min(len(want), earlyTerminationLimit))
+	})
+}
+
+func FuzzTakeWhile(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4, 1}, uint8(3))
+	f.Add([]byte{5, 1}, uint8(3))
+	f.Add([]byte{}, uint8(3))
+	f.Fuzz(func(t *testing.T, data []byte, limit uint8) {
+		s := ints(data)
+		less := func(v int) bool { return v < int(limit) }
+
+		want := s
+		if i := slices.IndexFunc(s, func(v int) bool { return !less(v) }); i >= 0 {
+			want = s[:i]
+		}
+		seq := TakeWhile(slices.Values(s), less)
+		itertest.AssertSeqEqual(t, seq, want)
+		itertest.CheckEarlyTermination(t, seq, min(len(want), earlyTerminationLimit))
+	})
+}
+
+func FuzzDropWhile(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4, 1}, uint8(3))
+	f.Add([]byte{1, 1}, uint8(3))
+	f.Add([]byte{}, uint8(3))
+	f.Fuzz(func(t *testing.T, data []byte, limit uint8) {
+		s := ints(data)
+		less := func(v int) bool { return v < int(limit) }
+
+		var want []int
+		if i := slices.IndexFunc(s, func(v int) bool { return !less(v) }); i >= 0 {
+			want = s[i:]
+		}
+		seq := DropWhile(slices.Values(s), less)
+		itertest.AssertSeqEqual(t, seq, want)
+		itertest.CheckEarlyTermination(t, seq, min(len(want), earlyTerminationLimit))
+	})
+}
+
+func FuzzChain(f *testing.F) {
+	f.Add([]byte{1, 2}, []byte{3})
+	f.Add([]byte{}, []byte{1})
+	f.Add([]byte{}, []byte{})
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		seq := Chain(slices.Values(ints(a)), slices.Values(ints(b)))
+		want := append(ints(a), ints(b)...)
+		itertest.AssertSeqEqual(t, seq, want)
+		itertest.CheckEarlyTermination(t, seq, min(len(want), earlyTerminationLimit))
+	})
+}
+
+func FuzzZip(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, []byte{4, 5})
+	f.Add([]byte{1}, []byte{4, 5, 6})
+	f.Add([]byte{}, []byte{4})
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		n := min(len(a), len(b))
+		wantKeys, wantValues := ints(a)[:n], ints(b)[:n]
+
+		seq := Zip(slices.Values(ints(a)), slices.Values(ints(b)))
+		itertest.AssertSeq2Equal(t, seq, wantKeys, wantValues)
+		itertest.CheckEarlyTermination(t, Keys(seq), min(n, earlyTerminationLimit))
+	})
+}
+
+func FuzzEnumerate(f *testing.F) {
+	f.Add([]byte{7, 8, 9})
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := ints(data)
+		wantKeys := make([]int, len(s))
+		for i := range s {
+			wantKeys[i] = i
+		}
+
+		seq := Enumerate(slices.Values(s))
+		itertest.AssertSeq2Equal(t, seq, wantKeys, s)
+		itertest.CheckEarlyTermination(t, Values(seq), min(len(s), earlyTerminationLimit))
+	})
+}
+
+func FuzzCycle(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, uint8(7))
+	f.Add([]byte{1}, uint8(0))
+	f.Add([]byte{}, uint8(5))
+	f.Fuzz(func(t *testing.T, data []byte, n uint8) {
+		s := ints(data)
+		var want []int
+		if len(s) > 0 {
+			want = make([]int, int(n))
+		}
+		for i := range want {
+			want[i] = s[i%len(s)]
+		}
+
+		seq := Take(Cycle(slices.Values(s)), int(n))
+		itertest.AssertSeqEqual(t, seq, want)
+		itertest.CheckEarlyTermination(t, seq, min(len(want), earlyTerminationLimit))
+	})
+}
@@ -0,0 +1,135 @@
+package itertools
+
+import (
+	"iter"
+)
+
+// Iterate returns an infinite sequence of `seed`, f(seed), f(f(seed)), and so on.
+//
+// Example:
+//
+//	powers := Iterate(1, func(n int) int { return n * 2 })
+//	for n := range Take(powers, 5) {
+//		fmt.Println(n) // 1, 2, 4, 8, 16
+//	}
+func Iterate[V any](seed V, f func(V) V) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		for v := seed; yield(v); v = f(v) {
+		}
+	})
+}
+
+// Iterate2 returns an infinite sequence of key-value pairs starting from (`k`, `v`),
+// where every next pair is obtained by applying `f` to the previous one.
+//
+// Example:
+//
+//	fib := Iterate2(0, 1, func(a, b int) (int, int) { return b, a + b })
+//	for a := range Keys(Take2(fib, 7)) {
+//		fmt.Println(a) // 0, 1, 1, 2, 3, 5, 8
+//	}
+func Iterate2[K, V any](k K, v V, f func(K, V) (K, V)) iter.Seq2[K, V] {
+	return checked2(func(yield func(K, V) bool) {
+		for k, v := k, v; yield(k, v); k, v = f(k, v) {
+		}
+	})
+}
+
+// Unfold returns a sequence generated from an initial `state`.
+// On every step `f` receives the current state and returns the next element,
+// the next state and whether the element should be yielded.
+// The sequence ends once `f` returns false.
+//
+// Example:
+//
+//	collatz := Unfold(6, func(n int) (int, int, bool) {
+//		switch {
+//		case n == 0:
+//			return 0, 0, false
+//		case n == 1:
+//			return 1, 0, true
+//		case n%2 == 0:
+//			return n, n / 2, true
+//		default:
+//			return n, 3*n + 1, true
+//		}
+//	})
+//	for n := range collatz {
+//		fmt.Println(n) // 6, 3, 10, 5, 16, 8, 4, 2, 1
+//	}
+func Unfold[S, V any](state S, f func(S) (V, S, bool)) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		s := state
+		for {
+			v, next, ok := f(s)
+			if !ok || !yield(v) {
+				return
+			}
+			s = next
+		}
+	})
+}
+
+// Unfold2 returns a sequence of key-value pairs generated from an initial `state`.
+// It works like Unfold, except that `f` returns a pair instead of a single element.
+//
+// Example:
+//
+//	type node struct {
+//		key  string
+//		val  int
+//		next *node
+//	}
+//	list := &node{"a", 1, &node{"b", 2, nil}}
+//	seq := Unfold2(list, func(n *node) (string, int, *node, bool) {
+//		if n == nil {
+//			return "", 0, nil, false
+//		}
+//		return n.key, n.val, n.next, true
+//	})
+//	for k, v := range seq {
+//		fmt.Println(k, v) // "a" 1, "b" 2
+//	}
+func Unfold2[S, K, V any](state S, f func(S) (K, V, S, bool)) iter.Seq2[K, V] {
+	return checked2(func(yield func(K, V) bool) {
+		s := state
+		for {
+			k, v, next, ok := f(s)
+			if !ok || !yield(k, v) {
+				return
+			}
+			s = next
+		}
+	})
+}
+
+// Generate returns an infinite sequence of the values returned by successive calls to `f`.
+//
+// Example:
+//
+//	dice := Generate(func() int { return rand.IntN(6) + 1 })
+//	for n := range Take(dice, 3) {
+//		fmt.Println(n) // e.g. 4, 1, 6
+//	}
+func Generate[V any](f func() V) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		for yield(f()) {
+		}
+	})
+}
+
+// Generate2 returns an infinite sequence of the key-value pairs returned by successive calls to `f`.
+//
+// Example:
+//
+//	i := 0
+//	squares := Generate2(func() (int, int) { i++; return i, i * i })
+//	for k, v := range Take2(squares, 3) {
+//		fmt.Println(k, v) // 1 1, 2 4, 3 9
+//	}
+func Generate2[K, V any](f func() (K, V)) iter.Seq2[K, V] {
+	return checked2(func(yield func(K, V) bool) {
+		for yield(f()) {
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package itertools
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestIterate(t *testing.T) {
+	powers := Iterate(1, func(n int) int { return n * 2 })
+	if got, want := slices.Collect(Take(powers, 5)), []int{1, 2, 4, 8, 16}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterate() = %v, want %v", got, want)
+	}
+}
+
+func TestIterate2(t *testing.T) {
+	fib := Iterate2(0, 1, func(a, b int) (int, int) { return b, a + b })
+	keys, values := unwrapIterator2(fib, 7)
+	if want := []int{0, 1, 1, 2, 3, 5, 8}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Iterate2() keys = %v, want %v", keys, want)
+	}
+	if want := []int{1, 1, 2, 3, 5, 8, 13}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Iterate2() values = %v, want %v", values, want)
+	}
+}
+
+func collatz(n int) (int, int, bool) {
+	switch {
+	case n == 0:
+		return 0, 0, false
+	case n == 1:
+		return 1, 0, true
+	case n%2 == 0:
+		return n, n / 2, true
+	default:
+		return n, 3*n + 1, true
+	}
+}
+
+func TestUnfold(t *testing.T) {
+	type testCase struct {
+		name  string
+		state int
+		want  []int
+	}
+	tests := []testCase{
+		{
+			name:  "collatz of 6",
+			state: 6,
+			want:  []int{6, 3, 10, 5, 16, 8, 4, 2, 1},
+		},
+		{
+			name:  "ends immediately",
+			state: 0,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Unfold(tt.state, collatz)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unfold() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnfold2(t *testing.T) {
+	type node struct {
+		key  string
+		val  int
+		next *node
+	}
+	list := &node{"a", 1, &node{"b", 2, &node{"c", 3, nil}}}
+	seq := Unfold2(list, func(n *node) (string, int, *node, bool) {
+		if n == nil {
+			return "", 0, nil, false
+		}
+		return n.key, n.val, n.next, true
+	})
+
+	keys, values := unwrapIterator2(seq, 5)
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Unfold2() keys = %v, want %v", keys, want)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Unfold2() values = %v, want %v", values, want)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	calls := 0
+	seq := Generate(func() int {
+		calls++
+		return calls * 10
+	})
+	if got, want := slices.Collect(Take(seq, 3)), []int{10, 20, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Generate() = %v, want %v", got, want)
+	}
+	if calls != 3 {
+		t.Errorf("Generate() called the function %d times, want 3", calls)
+	}
+}
+
+func TestGenerate2(t *testing.T) {
+	i := 0
+	squares := Generate2(func() (int, int) {
+		i++
+		return i, i * i
+	})
+	keys, values := unwrapIterator2(squares, 3)
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Generate2() keys = %v, want %v", keys, want)
+	}
+	if want := []int{1, 4, 9}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Generate2() values = %v, want %v", values, want)
+	}
+}
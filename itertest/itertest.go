@@ -0,0 +1,193 @@
+// Package itertest provides helpers for testing custom sequences.
+//
+// The checks verify both the produced elements and the behavior required
+// by the range-over-func contract: a sequence must stop as soon as yield
+// returns false, and it must not leave goroutines running behind.
+package itertest
+
+import (
+	"iter"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// equal compares slices with reflect.DeepEqual, treating nil and empty slices as equal.
+func equal[V any](got, want []V) bool {
+	if len(got) == 0 && len(want) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+// AssertSeqEqual reports an error if `seq` doesn't produce exactly the elements of `want`.
+// Elements are compared with reflect.DeepEqual.
+func AssertSeqEqual[V any](t testing.TB, seq iter.Seq[V], want []V) {
+	t.Helper()
+	got := make([]V, 0, len(want))
+	for v := range seq {
+		got = append(got, v)
+	}
+	if !equal(got, want) {
+		t.Errorf("sequence = %v, want %v", got, want)
+	}
+}
+
+// AssertSeq2Equal reports an error if `seq` doesn't produce exactly
+// the pairs formed by `wantKeys` and `wantValues`.
+// Keys and values are compared with reflect.DeepEqual.
+func AssertSeq2Equal[K, V any](t testing.TB, seq iter.Seq2[K, V], wantKeys []K, wantValues []V) {
+	t.Helper()
+	keys := make([]K, 0, len(wantKeys))
+	values := make([]V, 0, len(wantValues))
+	for k, v := range seq {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !equal(keys, wantKeys) {
+		t.Errorf("sequence keys = %v, want %v", keys, wantKeys)
+	}
+	if !equal(values, wantValues) {
+		t.Errorf("sequence values = %v, want %v", values, wantValues)
+	}
+}
+
+// CheckEarlyTermination stops iterating over `seq` after each of the first `limit` elements
+// and reports an error if the sequence calls yield again after it returned false.
+// It also works with infinite sequences.
+func CheckEarlyTermination[V any](t testing.TB, seq iter.Seq[V], limit int) {
+	t.Helper()
+	for stopAt := 1; stopAt <= limit; stopAt++ {
+		calls, stopped, extra := 0, false, 0
+		seq(func(V) bool {
+			if stopped {
+				extra++
+				return false
+			}
+			calls++
+			if calls == stopAt {
+				stopped = true
+				return false
+			}
+			return true
+		})
+		if extra > 0 {
+			t.Errorf("sequence called yield %d more times after it returned false at element %d", extra, stopAt)
+			return
+		}
+		if !stopped {
+			// The sequence is shorter than the limit.
+			return
+		}
+	}
+}
+
+// CheckRepeatable iterates over `seq` twice and reports an error
+// if the two iterations produce different elements.
+// Elements are compared with reflect.DeepEqual.
+func CheckRepeatable[V any](t testing.TB, seq iter.Seq[V]) {
+	t.Helper()
+	var first, second []V
+	for v := range seq {
+		first = append(first, v)
+	}
+	for v := range seq {
+		second = append(second, v)
+	}
+	if !equal(first, second) {
+		t.Errorf("sequence is not repeatable: first iteration = %v, second = %v", first, second)
+	}
+}
+
+// CheckNoGoroutineLeak calls `f` and reports an error if goroutines that weren't running
+// before are still running afterwards. Goroutines are given a second to finish.
+//
+// Goroutines are told apart by their IDs, so goroutines unrelated to `f`
+// exiting in the meantime don't hide a leak. Goroutines unrelated to `f`
+// started while it runs are reported as leaked, so it must not be used by parallel tests.
+func CheckNoGoroutineLeak(t testing.TB, f func()) {
+	t.Helper()
+	before := goroutines()
+	f()
+
+	deadline := time.Now().Add(time.Second)
+	leaked := newGoroutines(before)
+	for len(leaked) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		leaked = newGoroutines(before)
+	}
+	if len(leaked) > 0 {
+		t.Errorf("%d goroutines leaked:\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+	}
+}
+
+// goroutines returns the stack traces of all running goroutines keyed by their IDs.
+func goroutines() map[string]string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := make(map[string]string)
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		// Every trace starts with a header like "goroutine 42 [running]:".
+		header, _, _ := strings.Cut(stack, "\n")
+		fields := strings.Fields(header)
+		if len(fields) >= 2 && fields[0] == "goroutine" {
+			stacks[fields[1]] = stack
+		}
+	}
+	return stacks
+}
+
+// newGoroutines returns the stack traces of the running goroutines missing from `before`.
+func newGoroutines(before map[string]string) []string {
+	var leaked []string
+	for id, stack := range goroutines() {
+		if _, ok := before[id]; !ok {
+			leaked = append(leaked, stack)
+		}
+	}
+	return leaked
+}
+
+// Conformance runs a suite of subtests checking that the sequences returned by `factory`
+// produce `expected`, stop when asked to, can be iterated repeatedly
+// and don't leak goroutines when stopped early.
+//
+// A fresh sequence is obtained from `factory` for every subtest.
+//
+// Example:
+//
+//	func TestEvens(t *testing.T) {
+//		itertest.Conformance(t, func() iter.Seq[int] {
+//			return Evens(6)
+//		}, []int{0, 2, 4})
+//	}
+func Conformance[V any](t *testing.T, factory func() iter.Seq[V], expected []V) {
+	t.Helper()
+	t.Run("elements", func(t *testing.T) {
+		AssertSeqEqual(t, factory(), expected)
+	})
+	t.Run("early termination", func(t *testing.T) {
+		CheckEarlyTermination(t, factory(), len(expected))
+	})
+	t.Run("repeatable", func(t *testing.T) {
+		CheckRepeatable(t, factory())
+	})
+	t.Run("no goroutine leak", func(t *testing.T) {
+		seq := factory()
+		CheckNoGoroutineLeak(t, func() {
+			for range seq {
+				break
+			}
+		})
+	})
+}
@@ -0,0 +1,222 @@
+package itertest
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+)
+
+// recorder is a testing.TB recording the reported errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func elements(elems ...int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, v := range elems {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ignoresStop is a sequence that doesn't stop when yield returns false.
+func ignoresStop(yield func(int) bool) {
+	for i := range 3 {
+		yield(i)
+	}
+}
+
+func naturals(yield func(int) bool) {
+	for i := 0; ; i++ {
+		if !yield(i) {
+			return
+		}
+	}
+}
+
+func TestAssertSeqEqual(t *testing.T) {
+	type testCase struct {
+		name     string
+		seq      iter.Seq[int]
+		want     []int
+		wantFail bool
+	}
+	tests := []testCase{
+		{
+			name:     "equal",
+			seq:      elements(1, 2, 3),
+			want:     []int{1, 2, 3},
+			wantFail: false,
+		},
+		{
+			name:     "empty and nil",
+			seq:      elements(),
+			want:     nil,
+			wantFail: false,
+		},
+		{
+			name:     "different",
+			seq:      elements(1, 2),
+			want:     []int{1, 2, 3},
+			wantFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			AssertSeqEqual(r, tt.seq, tt.want)
+			if failed := len(r.errors) > 0; failed != tt.wantFail {
+				t.Errorf("AssertSeqEqual() failed = %v, want %v: %v", failed, tt.wantFail, r.errors)
+			}
+		})
+	}
+}
+
+func TestAssertSeq2Equal(t *testing.T) {
+	seq := func(yield func(int, string) bool) {
+		_ = yield(1, "a") && yield(2, "b")
+	}
+
+	r := &recorder{TB: t}
+	AssertSeq2Equal(r, seq, []int{1, 2}, []string{"a", "b"})
+	if len(r.errors) > 0 {
+		t.Errorf("AssertSeq2Equal() failed on equal sequences: %v", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	AssertSeq2Equal(r, seq, []int{1, 2}, []string{"a", "c"})
+	if len(r.errors) != 1 {
+		t.Errorf("AssertSeq2Equal() reported %v, want one error", r.errors)
+	}
+}
+
+func TestCheckEarlyTermination(t *testing.T) {
+	type testCase struct {
+		name     string
+		seq      iter.Seq[int]
+		wantFail bool
+	}
+	tests := []testCase{
+		{
+			name:     "well behaved",
+			seq:      elements(1, 2, 3),
+			wantFail: false,
+		},
+		{
+			name:     "infinite",
+			seq:      naturals,
+			wantFail: false,
+		},
+		{
+			name:     "ignores stop",
+			seq:      ignoresStop,
+			wantFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			CheckEarlyTermination(r, tt.seq, 5)
+			if failed := len(r.errors) > 0; failed != tt.wantFail {
+				t.Errorf("CheckEarlyTermination() failed = %v, want %v: %v", failed, tt.wantFail, r.errors)
+			}
+		})
+	}
+}
+
+func TestCheckRepeatable(t *testing.T) {
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	fromChan := func(yield func(int) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+
+	r := &recorder{TB: t}
+	CheckRepeatable(r, elements(1, 2))
+	if len(r.errors) > 0 {
+		t.Errorf("CheckRepeatable() failed on a repeatable sequence: %v", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	CheckRepeatable(r, fromChan)
+	if len(r.errors) != 1 {
+		t.Errorf("CheckRepeatable() reported %v, want one error", r.errors)
+	}
+}
+
+func TestCheckNoGoroutineLeak(t *testing.T) {
+	r := &recorder{TB: t}
+	CheckNoGoroutineLeak(r, func() {
+		next, stop := iter.Pull(naturals)
+		next()
+		stop()
+	})
+	if len(r.errors) > 0 {
+		t.Errorf("CheckNoGoroutineLeak() failed without a leak: %v", r.errors)
+	}
+
+	block, done := make(chan struct{}), make(chan struct{})
+	defer func() {
+		close(block)
+		<-done
+	}()
+	r = &recorder{TB: t}
+	CheckNoGoroutineLeak(r, func() {
+		go func() {
+			defer close(done)
+			<-block
+		}()
+	})
+	if len(r.errors) != 1 {
+		t.Errorf("CheckNoGoroutineLeak() reported %v, want one error", r.errors)
+	}
+}
+
+func TestCheckNoGoroutineLeakIgnoresExitingGoroutines(t *testing.T) {
+	// A goroutine started before the check and exiting during it
+	// must not cancel out the one leaked by f.
+	exiting, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		<-exiting
+	}()
+
+	block, done := make(chan struct{}), make(chan struct{})
+	defer func() {
+		close(block)
+		<-done
+	}()
+	r := &recorder{TB: t}
+	CheckNoGoroutineLeak(r, func() {
+		go func() {
+			defer close(done)
+			<-block
+		}()
+		close(exiting)
+		<-exited
+	})
+	if len(r.errors) != 1 {
+		t.Errorf("CheckNoGoroutineLeak() reported %v, want one error", r.errors)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	Conformance(t, func() iter.Seq[int] {
+		return elements(1, 2, 3)
+	}, []int{1, 2, 3})
+}
@@ -2,7 +2,7 @@ package itertools
 
 import (
 	"iter"
-	"sync"
+	"math"
 )
 
 // FromElements creates an iterator from a fixed list of elements.
@@ -14,13 +14,13 @@ import (
 //	    fmt.Println(v) // "a", "b", "c"
 //	}
 func FromElements[V any](elems ...V) iter.Seq[V] {
-	return func(yield func(V) bool) {
+	return checked(func(yield func(V) bool) {
 		for _, v := range elems {
 			if !yield(v) {
 				return
 			}
 		}
-	}
+	})
 }
 
 // FromPairs creates an iterator from a slice of key-value pairs.
@@ -32,13 +32,13 @@ func FromElements[V any](elems ...V) iter.Seq[V] {
 //	    fmt.Println(k, v) // "a" "1", "b" "2"
 //	}
 func FromPairs[V any](pairs [][2]V) iter.Seq2[V, V] {
-	return func(yield func(V, V) bool) {
+	return checked2(func(yield func(V, V) bool) {
 		for _, pair := range pairs {
 			if !yield(pair[0], pair[1]) {
 				return
 			}
 		}
-	}
+	})
 }
 
 // FromChan creates an iterator from a read-only channel.
@@ -57,13 +57,13 @@ func FromPairs[V any](pairs [][2]V) iter.Seq2[V, V] {
 //		    fmt.Println(k, v) // 1, 2, 3, 4, 5
 //		}
 func FromChan[V any](ch <-chan V) iter.Seq[V] {
-	return func(yield func(V) bool) {
+	return checked(func(yield func(V) bool) {
 		for v := range ch {
 			if !yield(v) {
 				return
 			}
 		}
-	}
+	})
 }
 
 // Keys extracts the keys from a key-value sequence.
@@ -75,13 +75,13 @@ func FromChan[V any](ch <-chan V) iter.Seq[V] {
 //	    fmt.Println(k, v) // "a", "b", "c"
 //	}
 func Keys[K, V any](iter iter.Seq2[K, V]) iter.Seq[K] {
-	return func(yield func(K) bool) {
+	return checked(func(yield func(K) bool) {
 		for k, _ := range iter {
 			if !yield(k) {
 				return
 			}
 		}
-	}
+	})
 }
 
 // Values extracts the values from a key-value sequence.
@@ -93,13 +93,13 @@ func Keys[K, V any](iter iter.Seq2[K, V]) iter.Seq[K] {
 //	    fmt.Println(k, v) // 1, 2, 3
 //	}
 func Values[K, V any](iter iter.Seq2[K, V]) iter.Seq[V] {
-	return func(yield func(V) bool) {
+	return checked(func(yield func(V) bool) {
 		for _, v := range iter {
 			if !yield(v) {
 				return
 			}
 		}
-	}
+	})
 }
 
 // Count returns an infinite sequence of numbers starting from `start`, incremented by `step`.
@@ -111,7 +111,7 @@ func Values[K, V any](iter iter.Seq2[K, V]) iter.Seq[V] {
 //		fmt.Println(n) // 10, 12, 14, 16, 18
 //	}
 func Count(start, step int) iter.Seq[int] {
-	return func(yield func(int) bool) {
+	return checked(func(yield func(int) bool) {
 		cur := start
 		for {
 			if !yield(cur) {
@@ -119,10 +119,11 @@ func Count(start, step int) iter.Seq[int] {
 			}
 			cur += step
 		}
-	}
+	})
 }
 
 // Cycle returns an infinite sequence cycling through elements of `iter`.
+// The sequence ends if a pass over `iter` produces no elements.
 //
 // Example:
 //
@@ -131,18 +132,24 @@ func Count(start, step int) iter.Seq[int] {
 //		fmt.Println(v) // "a", "b", "c", "a", "b"
 //	}
 func Cycle[V any](iter iter.Seq[V]) iter.Seq[V] {
-	return func(yield func(V) bool) {
+	return checked(func(yield func(V) bool) {
 		for {
+			empty := true
 			for v := range iter {
+				empty = false
 				if !yield(v) {
 					return
 				}
 			}
+			if empty {
+				return
+			}
 		}
-	}
+	})
 }
 
 // Cycle2 returns an infinite sequence cycling through key-value pairs of `iter`.
+// The sequence ends if a pass over `iter` produces no pairs.
 //
 // Example:
 //
@@ -151,15 +158,20 @@ func Cycle[V any](iter iter.Seq[V]) iter.Seq[V] {
 //		fmt.Println(k, v) // "a" "1", "b" "2", "a" "1", "b" "2"
 //	}
 func Cycle2[K, V any](iter iter.Seq2[K, V]) iter.Seq2[K, V] {
-	return func(yield func(K, V) bool) {
+	return checked2(func(yield func(K, V) bool) {
 		for {
+			empty := true
 			for k, v := range iter {
+				empty = false
 				if !yield(k, v) {
 					return
 				}
 			}
+			if empty {
+				return
+			}
 		}
-	}
+	})
 }
 
 // Repeat returns a sequence repeating `elem` exactly `n` times.
@@ -171,13 +183,68 @@ func Cycle2[K, V any](iter iter.Seq2[K, V]) iter.Seq2[K, V] {
 //		fmt.Println(v) // "hello", "hello", "hello"
 //	}
 func Repeat[V any](elem V, n int) iter.Seq[V] {
-	return func(yield func(V) bool) {
+	return checked(func(yield func(V) bool) {
 		for i := 0; i < n; i++ {
 			if !yield(elem) {
 				return
 			}
 		}
-	}
+	})
+}
+
+// RepeatForever returns an infinite sequence repeating `elem`.
+//
+// Example:
+//
+//	zeros := RepeatForever(0)
+//	for v := range Take(zeros, 3) {
+//		fmt.Println(v) // 0, 0, 0
+//	}
+func RepeatForever[V any](elem V) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		for yield(elem) {
+		}
+	})
+}
+
+// RepeatFunc returns a sequence of the values returned by `n` successive calls to `fn`.
+// Unlike Repeat, `fn` is called again for every element. See Generate for an infinite version.
+//
+// Example:
+//
+//	rows := RepeatFunc(func() []int { return make([]int, 2) }, 3)
+//	for row := range rows {
+//		fmt.Println(row) // [0 0], [0 0], [0 0], each a distinct slice
+//	}
+func RepeatFunc[V any](fn func() V, n int) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(fn()) {
+				return
+			}
+		}
+	})
+}
+
+// RepeatSeq returns a sequence iterating over `iter` exactly `n` times in a row.
+// It is a bounded version of Cycle.
+//
+// Example:
+//
+//	repeat := RepeatSeq(FromElements(1, 2), 3)
+//	for v := range repeat {
+//		fmt.Println(v) // 1, 2, 1, 2, 1, 2
+//	}
+func RepeatSeq[V any](iter iter.Seq[V], n int) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		for i := 0; i < n; i++ {
+			for v := range iter {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	})
 }
 
 // Chain returns a sequence that combines multiple sequences into one.
@@ -189,7 +256,7 @@ func Repeat[V any](elem V, n int) iter.Seq[V] {
 //		fmt.Println(v) // 1, 2, 3, 4
 //	}
 func Chain[V any](iters ...iter.Seq[V]) iter.Seq[V] {
-	return func(yield func(V) bool) {
+	return checked(func(yield func(V) bool) {
 		for _, it := range iters {
 			for v := range it {
 				if !yield(v) {
@@ -197,7 +264,7 @@ func Chain[V any](iters ...iter.Seq[V]) iter.Seq[V] {
 				}
 			}
 		}
-	}
+	})
 }
 
 // Chain2 returns a sequence that combines multiple key-value pair sequences into one.
@@ -212,7 +279,7 @@ func Chain[V any](iters ...iter.Seq[V]) iter.Seq[V] {
 //		fmt.Println(k, v) // "a" "1", "b" "2", "c" "3"
 //	}
 func Chain2[K, V any](iters ...iter.Seq2[K, V]) iter.Seq2[K, V] {
-	return func(yield func(K, V) bool) {
+	return checked2(func(yield func(K, V) bool) {
 		for _, it := range iters {
 			for k, v := range it {
 				if !yield(k, v) {
@@ -220,7 +287,7 @@ func Chain2[K, V any](iters ...iter.Seq2[K, V]) iter.Seq2[K, V] {
 				}
 			}
 		}
-	}
+	})
 }
 
 // Zip combines two iterables into a sequence of key-value pairs.
@@ -238,7 +305,7 @@ func Chain2[K, V any](iters ...iter.Seq2[K, V]) iter.Seq2[K, V] {
 //
 // Returns a sequence of key-value pairs formed by combining elements from the two sequences.
 func Zip[K, V any](first iter.Seq[K], second iter.Seq[V]) iter.Seq2[K, V] {
-	return func(yield func(K, V) bool) {
+	return checked2(func(yield func(K, V) bool) {
 		ch1 := make(chan K)
 		ch2 := make(chan V)
 		done := make(chan struct{})
@@ -294,15 +361,29 @@ func Zip[K, V any](first iter.Seq[K], second iter.Seq[V]) iter.Seq2[K, V] {
 				return
 			}
 		}
-	}
+	})
 }
 
-const slicePanicMessage = "itertools: step argument can't be negative"
+const (
+	slicePanicMessage = "itertools: step argument must be positive"
+	countPanicMessage = "itertools: count argument can't be negative"
+)
+
+// End can be passed as the `stop` argument of Slice and Slice2
+// to slice up to the end of the sequence.
+const End = math.MaxInt
 
 // Slice returns a sequence that includes elements from the input sequence `iter`
 // starting from index `start` to `stop`, with a specified `step`.
 //
-// It panics if `step` is negative.
+// Like in Python, negative `start` and `stop` are counted from the end of the sequence,
+// and a `stop` of End means slicing up to the end. Out of range indexes are clamped,
+// so `stop` preceding `start` results in an empty sequence.
+// Negative indexes require buffering, in a ring buffer bounded
+// by the absolute value of the index, and the input sequence must be finite
+// for elements relative to its end to be known.
+//
+// It panics if `step` is not positive.
 //
 // Example:
 //
@@ -311,31 +392,26 @@ const slicePanicMessage = "itertools: step argument can't be negative"
 //	for v := range slice {
 //		fmt.Println(v) // 2, 4
 //	}
+//
+//	slice = Slice(iter, -3, End, 1)
+//	for v := range slice {
+//		fmt.Println(v) // 4, 5, 6
+//	}
 func Slice[V any](iter iter.Seq[V], start, stop, step int) iter.Seq[V] {
-	if step < 0 {
+	if step <= 0 {
 		panic(slicePanicMessage)
 	}
 
-	return func(yield func(V) bool) {
-		i := 0
-		for v := range iter {
-			if i >= start && i < stop && (i-start)%step == 0 {
-				if !yield(v) {
-					return
-				}
-			}
-			i++
-			if i >= stop {
-				return
-			}
-		}
-	}
+	return checked(func(yield func(V) bool) {
+		slice(iter, start, stop, step, yield)
+	})
 }
 
 // Slice2 returns a sequence of key-value pairs from the input sequence `iter`
 // starting from index `start` to `stop`, with a specified `step`.
+// Indexes are interpreted as in Slice.
 //
-// It panics if `step` is negative.
+// It panics if `step` is not positive.
 //
 // Example:
 //
@@ -345,28 +421,112 @@ func Slice[V any](iter iter.Seq[V], start, stop, step int) iter.Seq[V] {
 //		fmt.Println(k, v) // "a" "1", "b" "2"
 //	}
 func Slice2[K, V any](iter iter.Seq2[K, V], start, stop, step int) iter.Seq2[K, V] {
-	if step < 0 {
+	if step <= 0 {
 		panic(slicePanicMessage)
 	}
-	return func(yield func(K, V) bool) {
+	return checked2(func(yield func(K, V) bool) {
+		slice(pairs(iter), start, stop, step, func(p pair[K, V]) bool {
+			return yield(p.k, p.v)
+		})
+	})
+}
+
+// slice yields the elements of `seq` selected by `start`, `stop` and `step`,
+// as described in Slice.
+func slice[E any](seq iter.Seq[E], start, stop, step int, yield func(E) bool) {
+	selected := func(i int) bool {
+		return i >= start && (i-start)%step == 0
+	}
+
+	switch {
+	case start >= 0 && stop >= 0:
+		if stop <= start {
+			return
+		}
 		i := 0
-		for k, v := range iter {
-			if i >= start && i < stop && (i-start)%step == 0 {
-				if !yield(k, v) {
-					return
-				}
+		for e := range seq {
+			if selected(i) && !yield(e) {
+				return
 			}
 			i++
 			if i >= stop {
 				return
 			}
 		}
+
+	case start >= 0:
+		// Elements are held back until -stop more elements follow them,
+		// which proves they are before stop.
+		held := newRing[E](-stop)
+		i := 0
+		for e := range seq {
+			if old, ok := held.push(e); ok && selected(i+stop) && !yield(old) {
+				return
+			}
+			i++
+		}
+
+	default:
+		// Only the last -start elements may be selected,
+		// and they are known once the sequence is exhausted.
+		last := newRing[E](-start)
+		n := 0
+		for e := range seq {
+			last.push(e)
+			n++
+		}
+		start = max(n+start, 0)
+		if stop < 0 {
+			stop += n
+		}
+		stop = min(stop, n)
+		for i := start; i < stop; i += step {
+			if !yield(last.at(i - (n - last.len()))) {
+				return
+			}
+		}
 	}
 }
 
+// ring is a bounded buffer holding the last elements pushed to it.
+// It grows as elements are pushed, so its size may exceed the length of the input.
+type ring[E any] struct {
+	buf   []E
+	first int
+	size  int
+}
+
+func newRing[E any](size int) *ring[E] {
+	return &ring[E]{size: size}
+}
+
+func (r *ring[E]) len() int {
+	return len(r.buf)
+}
+
+// at returns the element at index `i`, counting from the oldest one.
+func (r *ring[E]) at(i int) E {
+	return r.buf[(r.first+i)%len(r.buf)]
+}
+
+// push appends `e`, evicting and returning the oldest element if the buffer is full.
+func (r *ring[E]) push(e E) (E, bool) {
+	if len(r.buf) < r.size {
+		r.buf = append(r.buf, e)
+		var zero E
+		return zero, false
+	}
+	old := r.buf[r.first]
+	r.buf[r.first] = e
+	r.first = (r.first + 1) % len(r.buf)
+	return old, true
+}
+
 // Take returns a sequence containing the first n elements from the input sequence `iter`.
 // This is equivalent to applying Slice with start = 0, stop = n, and step = 1.
 //
+// It panics if `n` is negative.
+//
 // Example:
 //
 //	iter := FromElements(1, 2, 3, 4, 5)
@@ -375,12 +535,17 @@ func Slice2[K, V any](iter iter.Seq2[K, V], start, stop, step int) iter.Seq2[K,
 //		fmt.Println(v) // Output: 1, 2, 3
 //	}
 func Take[V any](iter iter.Seq[V], n int) iter.Seq[V] {
+	if n < 0 {
+		panic(countPanicMessage)
+	}
 	return Slice(iter, 0, n, 1)
 }
 
 // Take2 returns a sequence containing the first n key-value pairs from the input sequence `iter`.
 // This is equivalent to applying Slice2 with start = 0, stop = n, and step = 1.
 //
+// It panics if `n` is negative.
+//
 // Example:
 //
 //	iter := FromPairs([][2]string{{"a", "1"}, {"b", "2"}, {"c", "3"}})
@@ -389,9 +554,90 @@ func Take[V any](iter iter.Seq[V], n int) iter.Seq[V] {
 //		fmt.Println(k, v) // Output: "a" "1", "b" "2"
 //	}
 func Take2[K, V any](iter iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	if n < 0 {
+		panic(countPanicMessage)
+	}
 	return Slice2(iter, 0, n, 1)
 }
 
+// Drop returns a sequence of the elements of `iter` after the first n ones.
+// This is equivalent to applying Slice with start = n, stop = End, and step = 1.
+//
+// It panics if `n` is negative.
+//
+// Example:
+//
+//	iter := FromElements(1, 2, 3, 4, 5)
+//	dropped := Drop(iter, 3)
+//	for v := range dropped {
+//		fmt.Println(v) // Output: 4, 5
+//	}
+func Drop[V any](iter iter.Seq[V], n int) iter.Seq[V] {
+	if n < 0 {
+		panic(countPanicMessage)
+	}
+	return Slice(iter, n, End, 1)
+}
+
+// Drop2 returns a sequence of the key-value pairs of `iter` after the first n ones.
+// This is equivalent to applying Slice2 with start = n, stop = End, and step = 1.
+//
+// It panics if `n` is negative.
+func Drop2[K, V any](iter iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	if n < 0 {
+		panic(countPanicMessage)
+	}
+	return Slice2(iter, n, End, 1)
+}
+
+// Skip is an alias for Drop.
+func Skip[V any](iter iter.Seq[V], n int) iter.Seq[V] {
+	return Drop(iter, n)
+}
+
+// Skip2 is an alias for Drop2.
+func Skip2[K, V any](iter iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return Drop2(iter, n)
+}
+
+// Last returns a sequence containing the last n elements of the finite sequence `iter`.
+// This is equivalent to applying Slice with start = -n, stop = End, and step = 1.
+// At most n elements are buffered.
+//
+// It panics if `n` is negative.
+//
+// Example:
+//
+//	iter := FromElements(1, 2, 3, 4, 5)
+//	last := Last(iter, 2)
+//	for v := range last {
+//		fmt.Println(v) // Output: 4, 5
+//	}
+func Last[V any](iter iter.Seq[V], n int) iter.Seq[V] {
+	if n < 0 {
+		panic(countPanicMessage)
+	}
+	if n == 0 {
+		return Slice(iter, 0, 0, 1)
+	}
+	return Slice(iter, -n, End, 1)
+}
+
+// Last2 returns a sequence containing the last n key-value pairs of the finite sequence `iter`.
+// This is equivalent to applying Slice2 with start = -n, stop = End, and step = 1.
+// At most n pairs are buffered.
+//
+// It panics if `n` is negative.
+func Last2[K, V any](iter iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	if n < 0 {
+		panic(countPanicMessage)
+	}
+	if n == 0 {
+		return Slice2(iter, 0, 0, 1)
+	}
+	return Slice2(iter, -n, End, 1)
+}
+
 // TakeWhile returns a sequence of elements from `iter` as long
 // as the predicate function returns true for each element.
 // The sequence stops as soon as the predicate returns false.
@@ -404,13 +650,34 @@ func Take2[K, V any](iter iter.Seq2[K, V], n int) iter.Seq2[K, V] {
 //		fmt.Println(v) // Output: 1, 2, 3
 //	}
 func TakeWhile[V any](iter iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
-	return func(yield func(V) bool) {
+	return checked(func(yield func(V) bool) {
 		for v := range iter {
 			if !predicate(v) || !yield(v) {
 				return
 			}
 		}
-	}
+	})
+}
+
+// TakeWhile2 returns a sequence of key-value pairs from `iter` as long
+// as the predicate function returns true for each pair.
+// The sequence stops as soon as the predicate returns false.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b", "c"))
+//	taken := TakeWhile2(iter, func(i int, v string) bool { return i < 2 })
+//	for k, v := range taken {
+//		fmt.Println(k, v) // Output: 0 "a", 1 "b"
+//	}
+func TakeWhile2[K, V any](iter iter.Seq2[K, V], predicate func(K, V) bool) iter.Seq2[K, V] {
+	return checked2(func(yield func(K, V) bool) {
+		for k, v := range iter {
+			if !predicate(k, v) || !yield(k, v) {
+				return
+			}
+		}
+	})
 }
 
 // DropWhile returns a sequence of elements from `iter`
@@ -426,7 +693,7 @@ func TakeWhile[V any](iter iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
 //		fmt.Println(v) // Output: 3, 4, 5
 //	}
 func DropWhile[V any](iter iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
-	return func(yield func(V) bool) {
+	return checked(func(yield func(V) bool) {
 		skip := true
 		iter(func(v V) bool {
 			if skip {
@@ -437,7 +704,34 @@ func DropWhile[V any](iter iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
 			}
 			return yield(v)
 		})
-	}
+	})
+}
+
+// DropWhile2 returns a sequence of key-value pairs from `iter`
+// after the predicate function first returns false.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b", "c"))
+//	dropped := DropWhile2(iter, func(i int, v string) bool { return v != "b" })
+//	for k, v := range dropped {
+//		fmt.Println(k, v) // Output: 1 "b", 2 "c"
+//	}
+func DropWhile2[K, V any](iter iter.Seq2[K, V], predicate func(K, V) bool) iter.Seq2[K, V] {
+	return checked2(func(yield func(K, V) bool) {
+		skip := true
+		for k, v := range iter {
+			if skip {
+				if predicate(k, v) {
+					continue
+				}
+				skip = false
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	})
 }
 
 // Filter returns a new sequence containing only the elements from `iter`
@@ -451,13 +745,65 @@ func DropWhile[V any](iter iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
 //		fmt.Println(v) // 2, 4
 //	}
 func Filter[V any](iter iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
-	return func(yield func(V) bool) {
+	return checked(func(yield func(V) bool) {
 		for v := range iter {
 			if predicate(v) && !yield(v) {
 				return
 			}
 		}
-	}
+	})
+}
+
+// Filter2 returns a new sequence containing only the key-value pairs from `iter`
+// for which the predicate `predicate` returns true.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b", "c", "d"))
+//	even := Filter2(iter, func(i int, v string) bool { return i%2 == 0 })
+//	for k, v := range even {
+//		fmt.Println(k, v) // 0 "a", 2 "c"
+//	}
+func Filter2[K, V any](iter iter.Seq2[K, V], predicate func(K, V) bool) iter.Seq2[K, V] {
+	return checked2(func(yield func(K, V) bool) {
+		for k, v := range iter {
+			if predicate(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	})
+}
+
+// FilterKeys returns a new sequence containing only the key-value pairs from `iter`
+// whose key satisfies the predicate `predicate`.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b", "c"))
+//	filtered := FilterKeys(iter, func(i int) bool { return i > 0 })
+//	for k, v := range filtered {
+//		fmt.Println(k, v) // 1 "b", 2 "c"
+//	}
+func FilterKeys[K, V any](iter iter.Seq2[K, V], predicate func(K) bool) iter.Seq2[K, V] {
+	return Filter2(iter, func(k K, _ V) bool {
+		return predicate(k)
+	})
+}
+
+// FilterValues returns a new sequence containing only the key-value pairs from `iter`
+// whose value satisfies the predicate `predicate`.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b", "c"))
+//	filtered := FilterValues(iter, func(v string) bool { return v != "b" })
+//	for k, v := range filtered {
+//		fmt.Println(k, v) // 0 "a", 2 "c"
+//	}
+func FilterValues[K, V any](iter iter.Seq2[K, V], predicate func(V) bool) iter.Seq2[K, V] {
+	return Filter2(iter, func(_ K, v V) bool {
+		return predicate(v)
+	})
 }
 
 // Map returns a new sequence where each element from `iter`
@@ -471,13 +817,125 @@ func Filter[V any](iter iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
 //		fmt.Println(v) // 1, 4, 9
 //	}
 func Map[V, R any](iter iter.Seq[V], mapper func(V) R) iter.Seq[R] {
-	return func(yield func(R) bool) {
+	return checked(func(yield func(R) bool) {
 		for v := range iter {
 			if !yield(mapper(v)) {
 				return
 			}
 		}
-	}
+	})
+}
+
+// Map2 returns a new sequence where each key-value pair from `iter`
+// is transformed into another pair using the `mapper` function.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b"))
+//	mapped := Map2(iter, func(i int, v string) (string, int) { return v, i * 10 })
+//	for k, v := range mapped {
+//		fmt.Println(k, v) // "a" 0, "b" 10
+//	}
+func Map2[K, V, K2, V2 any](iter iter.Seq2[K, V], mapper func(K, V) (K2, V2)) iter.Seq2[K2, V2] {
+	return checked2(func(yield func(K2, V2) bool) {
+		for k, v := range iter {
+			if !yield(mapper(k, v)) {
+				return
+			}
+		}
+	})
+}
+
+// MapToSeq returns a new sequence where each key-value pair from `iter`
+// is transformed into a single element using the `mapper` function.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b"))
+//	mapped := MapToSeq(iter, func(i int, v string) string { return strconv.Itoa(i) + v })
+//	for v := range mapped {
+//		fmt.Println(v) // "0a", "1b"
+//	}
+func MapToSeq[K, V, R any](iter iter.Seq2[K, V], mapper func(K, V) R) iter.Seq[R] {
+	return checked(func(yield func(R) bool) {
+		for k, v := range iter {
+			if !yield(mapper(k, v)) {
+				return
+			}
+		}
+	})
+}
+
+// MapKeys returns a new sequence where the key of each pair from `iter`
+// is transformed using the `mapper` function, while the value is kept.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b"))
+//	mapped := MapKeys(iter, func(i int) int { return i + 1 })
+//	for k, v := range mapped {
+//		fmt.Println(k, v) // 1 "a", 2 "b"
+//	}
+func MapKeys[K, V, R any](iter iter.Seq2[K, V], mapper func(K) R) iter.Seq2[R, V] {
+	return Map2(iter, func(k K, v V) (R, V) {
+		return mapper(k), v
+	})
+}
+
+// MapValues returns a new sequence where the value of each pair from `iter`
+// is transformed using the `mapper` function, while the key is kept.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b"))
+//	mapped := MapValues(iter, strings.ToUpper)
+//	for k, v := range mapped {
+//		fmt.Println(k, v) // 0 "A", 1 "B"
+//	}
+func MapValues[K, V, R any](iter iter.Seq2[K, V], mapper func(V) R) iter.Seq2[K, R] {
+	return Map2(iter, func(k K, v V) (K, R) {
+		return k, mapper(v)
+	})
+}
+
+// Swap returns a new sequence where the key and the value of each pair from `iter` are swapped.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b"))
+//	for k, v := range Swap(iter) {
+//		fmt.Println(k, v) // "a" 0, "b" 1
+//	}
+func Swap[K, V any](iter iter.Seq2[K, V]) iter.Seq2[V, K] {
+	return checked2(func(yield func(V, K) bool) {
+		for k, v := range iter {
+			if !yield(v, k) {
+				return
+			}
+		}
+	})
+}
+
+// Accumulate returns a sequence of running results of combining the elements of `iter`.
+// Starting from `init`, every element is combined with the previous result using `f`,
+// and each new result is yielded. The initial value itself isn't yielded.
+//
+// Example:
+//
+//	sums := Accumulate(FromElements(1, 2, 3, 4), 0, func(acc, v int) int { return acc + v })
+//	for v := range sums {
+//		fmt.Println(v) // 1, 3, 6, 10
+//	}
+func Accumulate[V, A any](iter iter.Seq[V], init A, f func(A, V) A) iter.Seq[A] {
+	return checked(func(yield func(A) bool) {
+		acc := init
+		for v := range iter {
+			acc = f(acc, v)
+			if !yield(acc) {
+				return
+			}
+		}
+	})
 }
 
 // ForEach iterates over the elements of `iter`,
@@ -495,8 +953,24 @@ func ForEach[V any](iter iter.Seq[V], consumer func(V)) {
 	}
 }
 
+// ForEach2 iterates over the key-value pairs of `iter`,
+// applying the provided `consumer` function to each pair.
+//
+// Example:
+//
+//	iter := Enumerate(FromElements("a", "b"))
+//	ForEach2(iter, func(i int, v string) {
+//		fmt.Println(i, v)  // Output: 0 "a", 1 "b"
+//	})
+func ForEach2[K, V any](iter iter.Seq2[K, V], consumer func(K, V)) {
+	for k, v := range iter {
+		consumer(k, v)
+	}
+}
+
 // Once ensures that the given iterator can only be consumed once.
 // It panics if an attempt to iterate over the sequence a second time is made.
+// Use OnceWith to handle reuse differently.
 //
 // Example:
 //
@@ -510,23 +984,7 @@ func ForEach[V any](iter iter.Seq[V], consumer func(V)) {
 //	//	 fmt.Println(v) // panic
 //	// }
 func Once[V any](iter iter.Seq[V]) iter.Seq[V] {
-	mu := &sync.Mutex{}
-	consumed := false
-	return func(yield func(V) bool) {
-		mu.Lock()
-		if consumed {
-			mu.Unlock()
-			panic("itertools: iterator can only be consumed once")
-		}
-		consumed = true
-		mu.Unlock()
-
-		for v := range iter {
-			if !yield(v) {
-				return
-			}
-		}
-	}
+	return OnceWith(iter, OnceOptions{})
 }
 
 // Enumerate returns a sequence of pairs (index, value) from the given sequence.
@@ -541,7 +999,7 @@ func Once[V any](iter iter.Seq[V]) iter.Seq[V] {
 //		fmt.Println(i, v) // 0 "a", 1 "b", 2 "c"
 //	}
 func Enumerate[V any](iter iter.Seq[V]) iter.Seq2[int, V] {
-	return func(yield func(int, V) bool) {
+	return checked2(func(yield func(int, V) bool) {
 		i := 0
 		for v := range iter {
 			if !yield(i, v) {
@@ -549,5 +1007,35 @@ func Enumerate[V any](iter iter.Seq[V]) iter.Seq2[int, V] {
 			}
 			i++
 		}
-	}
+	})
+}
+
+// UntilError returns a sequence of the values from a sequence of (value, error) pairs,
+// stopping at the first non-nil error. The error is stored in `err`,
+// which is left untouched if the sequence ends without one.
+//
+// It allows fallible sources to be used with operators accepting plain sequences.
+//
+// Example:
+//
+//	var err error
+//	records := UntilError(csvseq.Records(r), &err)
+//	for rec := range Take(records, 10) {
+//		fmt.Println(rec)
+//	}
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func UntilError[V any](iter iter.Seq2[V, error], err *error) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		for v, e := range iter {
+			if e != nil {
+				*err = e
+				return
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	})
 }
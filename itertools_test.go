@@ -1,7 +1,9 @@
 package itertools
 
 import (
+	"errors"
 	"iter"
+	"math"
 	"reflect"
 	"slices"
 	"strconv"
@@ -108,6 +110,14 @@ func TestCycle(t *testing.T) {
 			limit: 5,
 			want:  []int{-10, 0, -10, 0, -10},
 		},
+		{
+			name: "empty cycle ends",
+			args: args[int]{
+				FromElements[int](),
+			},
+			limit: 5,
+			want:  nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -140,6 +150,15 @@ func TestCycle2(t *testing.T) {
 			wantKeys:   []int{2, 4, 6, 2, 4, 6, 2, 4, 6},
 			wantValues: []string{"a", "b", "c", "a", "b", "c", "a", "b", "c"},
 		},
+		{
+			name: "empty cycle ends",
+			args: args[int, string]{
+				Zip(FromElements[int](), FromElements("a")),
+			},
+			limit:      5,
+			wantKeys:   []int{},
+			wantValues: []string{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -184,6 +203,65 @@ func TestRepeat(t *testing.T) {
 	}
 }
 
+func TestRepeatForever(t *testing.T) {
+	if got, want := slices.Collect(Take(RepeatForever("a"), 3)), []string{"a", "a", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RepeatForever() = %v, want %v", got, want)
+	}
+}
+
+func TestRepeatFunc(t *testing.T) {
+	calls := 0
+	next := func() int {
+		calls++
+		return calls
+	}
+	if got, want := slices.Collect(RepeatFunc(next, 4)), []int{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RepeatFunc() = %v, want %v", got, want)
+	}
+	if got := slices.Collect(RepeatFunc(next, 0)); got != nil {
+		t.Errorf("RepeatFunc() = %v, want []", got)
+	}
+	if calls != 4 {
+		t.Errorf("RepeatFunc() called the function %d times, want 4", calls)
+	}
+}
+
+func TestRepeatSeq(t *testing.T) {
+	type args struct {
+		iter iter.Seq[int]
+		n    int
+	}
+	type testCase struct {
+		name string
+		args args
+		want []int
+	}
+	tests := []testCase{
+		{
+			name: "[1, 2] 3 times",
+			args: args{FromElements(1, 2), 3},
+			want: []int{1, 2, 1, 2, 1, 2},
+		},
+		{
+			name: "zero times",
+			args: args{FromElements(1, 2), 0},
+			want: nil,
+		},
+		{
+			name: "empty sequence",
+			args: args{FromElements[int](), 3},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(RepeatSeq(tt.args.iter, tt.args.n)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RepeatSeq() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestChain(t *testing.T) {
 	type args struct {
 		iters []iter.Seq[int]
@@ -337,6 +415,66 @@ func TestSlice(t *testing.T) {
 			},
 			want: []int{2, 4},
 		},
+		{
+			name: "stop before start",
+			args: args{
+				iter:  FromElements(1, 2, 3, 4, 5),
+				start: 3,
+				stop:  1,
+				step:  1,
+			},
+			want: nil,
+		},
+		{
+			name: "open stop",
+			args: args{
+				iter:  FromElements(1, 2, 3, 4, 5),
+				start: 1,
+				stop:  End,
+				step:  2,
+			},
+			want: []int{2, 4},
+		},
+		{
+			name: "negative start",
+			args: args{
+				iter:  FromElements(1, 2, 3, 4, 5),
+				start: -3,
+				stop:  End,
+				step:  1,
+			},
+			want: []int{3, 4, 5},
+		},
+		{
+			name: "negative start beyond the beginning",
+			args: args{
+				iter:  FromElements(1, 2, 3),
+				start: -10,
+				stop:  2,
+				step:  1,
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "negative stop",
+			args: args{
+				iter:  FromElements(1, 2, 3, 4, 5, 6),
+				start: 1,
+				stop:  -1,
+				step:  2,
+			},
+			want: []int{2, 4},
+		},
+		{
+			name: "negative start and stop",
+			args: args{
+				iter:  FromElements(1, 2, 3, 4, 5, 6),
+				start: -4,
+				stop:  -1,
+				step:  2,
+			},
+			want: []int{3, 5},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -543,3 +681,463 @@ func TestEnumerate(t *testing.T) {
 		})
 	}
 }
+
+func TestUntilError(t *testing.T) {
+	errBoom := errors.New("boom")
+	type testCase struct {
+		name    string
+		iter    iter.Seq2[int, error]
+		want    []int
+		wantErr error
+	}
+	tests := []testCase{
+		{
+			name: "no error",
+			iter: func(yield func(int, error) bool) {
+				_ = yield(1, nil) && yield(2, nil)
+			},
+			want:    []int{1, 2},
+			wantErr: nil,
+		},
+		{
+			name: "error in the middle",
+			iter: func(yield func(int, error) bool) {
+				_ = yield(1, nil) && yield(0, errBoom) && yield(3, nil)
+			},
+			want:    []int{1},
+			wantErr: errBoom,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if got := slices.Collect(UntilError(tt.iter, &err)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UntilError() = %v, want %v", got, tt.want)
+			}
+			if err != tt.wantErr {
+				t.Errorf("UntilError() err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlice2(t *testing.T) {
+	iter := FromPairs([][2]string{{"a", "1"}, {"b", "2"}, {"c", "3"}, {"d", "4"}})
+	keys, values := unwrapIterator2(Slice2(iter, -3, -1, 1), 4)
+	if want := []string{"b", "c"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Slice2() keys = %v, want %v", keys, want)
+	}
+	if want := []string{"2", "3"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Slice2() values = %v, want %v", values, want)
+	}
+}
+
+func TestSliceHugeNegativeIndexes(t *testing.T) {
+	// The buffers are bounded by the indexes, but only grow with the input.
+	if got, want := slices.Collect(Slice(FromElements(1, 2, 3), -(1<<40), End, 1)), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Slice() = %v, want %v", got, want)
+	}
+	if got := slices.Collect(Slice(FromElements(1, 2, 3), 0, -(1 << 40), 1)); got != nil {
+		t.Errorf("Slice() = %v, want []", got)
+	}
+}
+
+func TestSlicePanics(t *testing.T) {
+	type testCase struct {
+		name string
+		f    func()
+	}
+	tests := []testCase{
+		{
+			name: "zero step",
+			f:    func() { Slice(FromElements(1), 0, 1, 0) },
+		},
+		{
+			name: "negative step",
+			f:    func() { Slice2(Enumerate(FromElements(1)), 0, 1, -1) },
+		},
+		{
+			name: "negative take",
+			f:    func() { Take(FromElements(1), -1) },
+		},
+		{
+			name: "negative drop",
+			f:    func() { Drop(FromElements(1), -1) },
+		},
+		{
+			name: "negative last",
+			f:    func() { Last(FromElements(1), -1) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic, but did not get one")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
+
+func TestDrop(t *testing.T) {
+	type testCase struct {
+		name string
+		n    int
+		want []int
+	}
+	tests := []testCase{
+		{
+			name: "drop 3",
+			n:    3,
+			want: []int{4, 5},
+		},
+		{
+			name: "drop none",
+			n:    0,
+			want: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "drop all",
+			n:    10,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Drop(FromElements(1, 2, 3, 4, 5), tt.n)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Drop() = %v, want %v", got, tt.want)
+			}
+			if got := slices.Collect(Skip(FromElements(1, 2, 3, 4, 5), tt.n)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Skip() = %v, want %v", got, tt.want)
+			}
+			if got := slices.Collect(Values(Drop2(Enumerate(FromElements(1, 2, 3, 4, 5)), tt.n))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Drop2() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLast(t *testing.T) {
+	type testCase struct {
+		name string
+		n    int
+		want []int
+	}
+	tests := []testCase{
+		{
+			name: "last 2",
+			n:    2,
+			want: []int{4, 5},
+		},
+		{
+			name: "last 0",
+			n:    0,
+			want: nil,
+		},
+		{
+			name: "more than available",
+			n:    10,
+			want: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "huge n",
+			n:    math.MaxInt / 2,
+			want: []int{1, 2, 3, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Last(FromElements(1, 2, 3, 4, 5), tt.n)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Last() = %v, want %v", got, tt.want)
+			}
+			if got := slices.Collect(Values(Last2(Enumerate(FromElements(1, 2, 3, 4, 5)), tt.n))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Last2() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTakeWhile2(t *testing.T) {
+	type args struct {
+		predicate func(int, int) bool
+		iter      iter.Seq2[int, int]
+	}
+	type testCase struct {
+		name       string
+		args       args
+		wantKeys   []int
+		wantValues []int
+	}
+	tests := []testCase{
+		{
+			name: "< 4",
+			args: args{
+				predicate: func(_, v int) bool {
+					return v < 4
+				},
+				iter: Enumerate(FromElements(1, 2, 3, 4, 5)),
+			},
+			wantKeys:   []int{0, 1, 2},
+			wantValues: []int{1, 2, 3},
+		},
+		{
+			name: "mod 2",
+			args: args{
+				predicate: func(_, v int) bool {
+					return v%2 == 0
+				},
+				iter: Enumerate(FromElements(2, 2, 3, 4, 5)),
+			},
+			wantKeys:   []int{0, 1},
+			wantValues: []int{2, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tw := TakeWhile2(tt.args.iter, tt.args.predicate)
+			keys, values := unwrapIterator2(tw, 5)
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("TakeWhile2() keys = %v, want %v", keys, tt.wantKeys)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("TakeWhile2() values = %v, want %v", values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestDropWhile2(t *testing.T) {
+	type args struct {
+		predicate func(int, int) bool
+		iter      iter.Seq2[int, int]
+	}
+	type testCase struct {
+		name       string
+		args       args
+		wantKeys   []int
+		wantValues []int
+	}
+	tests := []testCase{
+		{
+			name: "< 3",
+			args: args{
+				predicate: func(_, v int) bool {
+					return v < 3
+				},
+				iter: Enumerate(FromElements(1, 2, 3, 4, 5)),
+			},
+			wantKeys:   []int{2, 3, 4},
+			wantValues: []int{3, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dw := DropWhile2(tt.args.iter, tt.args.predicate)
+			keys, values := unwrapIterator2(dw, 5)
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("DropWhile2() keys = %v, want %v", keys, tt.wantKeys)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("DropWhile2() values = %v, want %v", values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestFilter2(t *testing.T) {
+	type args struct {
+		iter      iter.Seq2[int, int]
+		predicate func(int, int) bool
+	}
+	type testCase struct {
+		name       string
+		args       args
+		wantKeys   []int
+		wantValues []int
+	}
+	tests := []testCase{
+		{
+			name: "simple",
+			args: args{
+				iter:      Enumerate(FromElements(1, 2, 3, 4, 5)),
+				predicate: func(_, v int) bool { return v%2 == 0 },
+			},
+			wantKeys:   []int{1, 3},
+			wantValues: []int{2, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := Filter2(tt.args.iter, tt.args.predicate)
+			keys, values := unwrapIterator2(f, 5)
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("Filter2() keys = %v, want %v", keys, tt.wantKeys)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("Filter2() values = %v, want %v", values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestFilterKeysAndValues(t *testing.T) {
+	type testCase struct {
+		name       string
+		filter     func(iter.Seq2[int, int]) iter.Seq2[int, int]
+		wantKeys   []int
+		wantValues []int
+	}
+	tests := []testCase{
+		{
+			name: "keys",
+			filter: func(it iter.Seq2[int, int]) iter.Seq2[int, int] {
+				return FilterKeys(it, func(k int) bool { return k%2 == 0 })
+			},
+			wantKeys:   []int{0, 2, 4},
+			wantValues: []int{1, 3, 5},
+		},
+		{
+			name: "values",
+			filter: func(it iter.Seq2[int, int]) iter.Seq2[int, int] {
+				return FilterValues(it, func(v int) bool { return v%2 == 0 })
+			},
+			wantKeys:   []int{1, 3},
+			wantValues: []int{2, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, values := unwrapIterator2(tt.filter(Enumerate(FromElements(1, 2, 3, 4, 5))), 5)
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("Filter%s() keys = %v, want %v", tt.name, keys, tt.wantKeys)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("Filter%s() values = %v, want %v", tt.name, values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestMap2(t *testing.T) {
+	type args struct {
+		iter   iter.Seq2[int, int]
+		mapper func(int, int) (string, int)
+	}
+	type testCase struct {
+		name       string
+		args       args
+		wantKeys   []string
+		wantValues []int
+	}
+	tests := []testCase{
+		{
+			name: "simple",
+			args: args{
+				iter:   Enumerate(FromElements(1, 2, 3, 4, 5)),
+				mapper: func(i, v int) (string, int) { return strconv.Itoa(v), i * 10 },
+			},
+			wantKeys:   []string{"1", "2", "3", "4", "5"},
+			wantValues: []int{0, 10, 20, 30, 40},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Map2(tt.args.iter, tt.args.mapper)
+			keys, values := unwrapIterator2(m, 5)
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("Map2() keys = %v, want %v", keys, tt.wantKeys)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("Map2() values = %v, want %v", values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestMapToSeq(t *testing.T) {
+	type args struct {
+		iter   iter.Seq2[int, int]
+		mapper func(int, int) string
+	}
+	type testCase struct {
+		name string
+		args args
+		want []string
+	}
+	tests := []testCase{
+		{
+			name: "simple",
+			args: args{
+				iter:   Enumerate(FromElements(1, 2, 3, 4, 5)),
+				mapper: func(i, v int) string { return strconv.Itoa(i) + ":" + strconv.Itoa(v) },
+			},
+			want: []string{"0:1", "1:2", "2:3", "3:4", "4:5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := MapToSeq(tt.args.iter, tt.args.mapper)
+			if got := slices.Collect(m); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MapToSeq() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapKeysAndValues(t *testing.T) {
+	seq := Enumerate(FromElements(1, 2, 3))
+
+	keys, values := unwrapIterator2(MapKeys(seq, strconv.Itoa), 3)
+	if want := []string{"0", "1", "2"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("MapKeys() keys = %v, want %v", keys, want)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(values, want) {
+		t.Errorf("MapKeys() values = %v, want %v", values, want)
+	}
+
+	keys2, values2 := unwrapIterator2(MapValues(seq, strconv.Itoa), 3)
+	if want := []int{0, 1, 2}; !reflect.DeepEqual(keys2, want) {
+		t.Errorf("MapValues() keys = %v, want %v", keys2, want)
+	}
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(values2, want) {
+		t.Errorf("MapValues() values = %v, want %v", values2, want)
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	sums := Accumulate(FromElements(1, 2, 3, 4), 0, func(acc, v int) int { return acc + v })
+	if got, want := slices.Collect(sums), []int{1, 3, 6, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Accumulate() = %v, want %v", got, want)
+	}
+
+	joined := Accumulate(FromElements("a", "b", "c"), ">", func(acc, v string) string { return acc + v })
+	if got, want := slices.Collect(joined), []string{">a", ">ab", ">abc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Accumulate() = %v, want %v", got, want)
+	}
+
+	if got := slices.Collect(Accumulate(FromElements[int](), 0, func(acc, v int) int { return acc + v })); got != nil {
+		t.Errorf("Accumulate() = %v, want []", got)
+	}
+}
+
+func TestSwap(t *testing.T) {
+	keys, values := unwrapIterator2(Swap(Enumerate(FromElements("a", "b", "c"))), 3)
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Swap() keys = %v, want %v", keys, want)
+	}
+	if want := []int{0, 1, 2}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Swap() values = %v, want %v", values, want)
+	}
+}
+
+func TestForEach2(t *testing.T) {
+	var got []string
+	ForEach2(Enumerate(FromElements("a", "b")), func(i int, v string) {
+		got = append(got, strconv.Itoa(i)+v)
+	})
+	if want := []string{"0a", "1b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ForEach2() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,220 @@
+package itertools
+
+import (
+	"iter"
+)
+
+// Optional holds a value that may be missing,
+// such as the side of an outer join without a matching element.
+type Optional[V any] struct {
+	Value V    // The value, or the zero value if it is missing
+	Ok    bool // Whether the value is present
+}
+
+// index groups the elements of `seq` by `key`, keeping their order within each group.
+func index[V any, K comparable](seq iter.Seq[V], key func(V) K) map[K][]V {
+	groups := make(map[K][]V)
+	for v := range seq {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// HashJoin returns a sequence of the pairs of elements of `left` and `right` with equal keys,
+// like an inner join in SQL. The keys are computed by `leftKey` and `rightKey`.
+//
+// All of `right` is loaded into a hash table before the first pair is yielded,
+// while `left` is streamed, so `right` should be the smaller side.
+// The pairs are ordered by `left`, then by `right` for the same left element.
+//
+// Example:
+//
+//	type user struct {
+//		id   int
+//		name string
+//	}
+//	type order struct {
+//		userID int
+//		item   string
+//	}
+//	users := FromElements(user{1, "ann"}, user{2, "bob"})
+//	orders := FromElements(order{1, "book"}, order{1, "pen"}, order{3, "cup"})
+//	joined := HashJoin(users, orders, func(u user) int { return u.id }, func(o order) int { return o.userID })
+//	for u, o := range joined {
+//		fmt.Println(u.name, o.item) // "ann" "book", "ann" "pen"
+//	}
+func HashJoin[L, R any, K comparable](left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K) iter.Seq2[L, R] {
+	return checked2(func(yield func(L, R) bool) {
+		table := index(right, rightKey)
+		for l := range left {
+			for _, r := range table[leftKey(l)] {
+				if !yield(l, r) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// LeftJoin is like HashJoin, but also yields the elements of `left`
+// without a matching element in `right`, paired with a missing value.
+//
+// Example:
+//
+//	joined := LeftJoin(users, orders, func(u user) int { return u.id }, func(o order) int { return o.userID })
+//	for u, o := range joined {
+//		fmt.Println(u.name, o.Value.item, o.Ok) // "ann" "book" true, "ann" "pen" true, "bob" "" false
+//	}
+func LeftJoin[L, R any, K comparable](left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K) iter.Seq2[L, Optional[R]] {
+	return checked2(func(yield func(L, Optional[R]) bool) {
+		table := index(right, rightKey)
+		for l := range left {
+			matches := table[leftKey(l)]
+			if len(matches) == 0 {
+				if !yield(l, Optional[R]{}) {
+					return
+				}
+				continue
+			}
+			for _, r := range matches {
+				if !yield(l, Optional[R]{r, true}) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// RightJoin is like HashJoin, but also yields the elements of `right`
+// without a matching element in `left`, paired with a missing value.
+// Unlike the other joins, all of `left` is loaded into a hash table,
+// `right` is streamed, and the pairs are ordered by `right`.
+//
+// Example:
+//
+//	joined := RightJoin(users, orders, func(u user) int { return u.id }, func(o order) int { return o.userID })
+//	for u, o := range joined {
+//		fmt.Println(u.Value.name, u.Ok, o.item) // "ann" true "book", "ann" true "pen", "" false "cup"
+//	}
+func RightJoin[L, R any, K comparable](left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K) iter.Seq2[Optional[L], R] {
+	return checked2(func(yield func(Optional[L], R) bool) {
+		table := index(left, leftKey)
+		for r := range right {
+			matches := table[rightKey(r)]
+			if len(matches) == 0 {
+				if !yield(Optional[L]{}, r) {
+					return
+				}
+				continue
+			}
+			for _, l := range matches {
+				if !yield(Optional[L]{l, true}, r) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// FullJoin is like HashJoin, but also yields the elements of either side
+// without a matching element on the other one, paired with a missing value.
+// The pairs are yielded as for LeftJoin, followed by the unmatched elements
+// of `right` in their original order.
+//
+// Example:
+//
+//	joined := FullJoin(users, orders, func(u user) int { return u.id }, func(o order) int { return o.userID })
+//	for u, o := range joined {
+//		fmt.Println(u.Value.name, o.Value.item)
+//		// "ann" "book", "ann" "pen", "bob" "", "" "cup"
+//	}
+func FullJoin[L, R any, K comparable](left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K) iter.Seq2[Optional[L], Optional[R]] {
+	return checked2(func(yield func(Optional[L], Optional[R]) bool) {
+		var rights []pair[K, R]
+		table := make(map[K][]R)
+		for r := range right {
+			k := rightKey(r)
+			rights = append(rights, pair[K, R]{k, r})
+			table[k] = append(table[k], r)
+		}
+
+		matched := make(map[K]bool)
+		for l := range left {
+			k := leftKey(l)
+			matches := table[k]
+			if len(matches) == 0 {
+				if !yield(Optional[L]{l, true}, Optional[R]{}) {
+					return
+				}
+				continue
+			}
+			matched[k] = true
+			for _, r := range matches {
+				if !yield(Optional[L]{l, true}, Optional[R]{r, true}) {
+					return
+				}
+			}
+		}
+
+		for _, p := range rights {
+			if matched[p.k] {
+				continue
+			}
+			if !yield(Optional[L]{}, Optional[R]{p.v, true}) {
+				return
+			}
+		}
+	})
+}
+
+// MergeJoin returns a sequence of the pairs of elements of `left` and `right` with equal keys,
+// given that both are sorted by key according to `cmp`.
+// Unlike HashJoin, neither side is loaded into memory: both are streamed
+// in a single pass, and only the elements of `right` sharing the current key
+// are kept, to pair them with every element of `left` having that key.
+// Inputs that aren't sorted produce unspecified results.
+//
+// Example:
+//
+//	users := FromElements(user{1, "ann"}, user{2, "bob"})
+//	orders := FromElements(order{1, "book"}, order{1, "pen"}, order{2, "cup"})
+//	joined := MergeJoin(users, orders, func(u user) int { return u.id }, func(o order) int { return o.userID }, cmp.Compare[int])
+//	for u, o := range joined {
+//		fmt.Println(u.name, o.item) // "ann" "book", "ann" "pen", "bob" "cup"
+//	}
+func MergeJoin[L, R, K any](left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K, cmp func(K, K) int) iter.Seq2[L, R] {
+	return checked2(func(yield func(L, R) bool) {
+		nextLeft, stopLeft := iter.Pull(left)
+		defer stopLeft()
+		nextRight, stopRight := iter.Pull(right)
+		defer stopRight()
+
+		l, lok := nextLeft()
+		r, rok := nextRight()
+		var group []R
+		for lok && rok {
+			lk, rk := leftKey(l), rightKey(r)
+			switch c := cmp(lk, rk); {
+			case c < 0:
+				l, lok = nextLeft()
+			case c > 0:
+				r, rok = nextRight()
+			default:
+				group = group[:0]
+				for rok && cmp(rightKey(r), lk) == 0 {
+					group = append(group, r)
+					r, rok = nextRight()
+				}
+				for lok && cmp(leftKey(l), lk) == 0 {
+					for _, g := range group {
+						if !yield(l, g) {
+							return
+						}
+					}
+					l, lok = nextLeft()
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package itertools
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/dzherb/go-itertools/itertest"
+)
+
+type joinUser struct {
+	id   int
+	name string
+}
+
+type joinOrder struct {
+	userID int
+	item   string
+}
+
+func userID(u joinUser) int     { return u.id }
+func orderUser(o joinOrder) int { return o.userID }
+
+var (
+	joinUsers  = []joinUser{{1, "ann"}, {2, "bob"}, {4, "dan"}}
+	joinOrders = []joinOrder{{3, "cup"}, {1, "book"}, {4, "lamp"}, {1, "pen"}, {5, "mug"}}
+)
+
+// names describes the joined pairs as strings, with "-" for missing values.
+func names(u Optional[joinUser], o Optional[joinOrder]) string {
+	s := "-"
+	if u.Ok {
+		s = u.Value.name
+	}
+	if o.Ok {
+		return s + ":" + o.Value.item
+	}
+	return s + ":-"
+}
+
+func TestHashJoin(t *testing.T) {
+	var got []string
+	for u, o := range HashJoin(slices.Values(joinUsers), slices.Values(joinOrders), userID, orderUser) {
+		got = append(got, names(Optional[joinUser]{u, true}, Optional[joinOrder]{o, true}))
+	}
+	if want := []string{"ann:book", "ann:pen", "dan:lamp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HashJoin() = %v, want %v", got, want)
+	}
+}
+
+func TestHashJoinFromChan(t *testing.T) {
+	ch := make(chan joinOrder, len(joinOrders))
+	for _, o := range joinOrders {
+		ch <- o
+	}
+	close(ch)
+
+	var got []string
+	for u, o := range HashJoin(slices.Values(joinUsers), FromChan(ch), userID, orderUser) {
+		got = append(got, u.name+":"+o.item)
+	}
+	if want := []string{"ann:book", "ann:pen", "dan:lamp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HashJoin() = %v, want %v", got, want)
+	}
+}
+
+func TestLeftJoin(t *testing.T) {
+	var got []string
+	for u, o := range LeftJoin(slices.Values(joinUsers), slices.Values(joinOrders), userID, orderUser) {
+		got = append(got, names(Optional[joinUser]{u, true}, o))
+	}
+	if want := []string{"ann:book", "ann:pen", "bob:-", "dan:lamp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LeftJoin() = %v, want %v", got, want)
+	}
+}
+
+func TestRightJoin(t *testing.T) {
+	var got []string
+	for u, o := range RightJoin(slices.Values(joinUsers), slices.Values(joinOrders), userID, orderUser) {
+		got = append(got, names(u, Optional[joinOrder]{o, true}))
+	}
+	if want := []string{"-:cup", "ann:book", "dan:lamp", "ann:pen", "-:mug"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RightJoin() = %v, want %v", got, want)
+	}
+}
+
+func TestFullJoin(t *testing.T) {
+	var got []string
+	for u, o := range FullJoin(slices.Values(joinUsers), slices.Values(joinOrders), userID, orderUser) {
+		got = append(got, names(u, o))
+	}
+	if want := []string{"ann:book", "ann:pen", "bob:-", "dan:lamp", "-:cup", "-:mug"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FullJoin() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeJoin(t *testing.T) {
+	type testCase struct {
+		name   string
+		users  []joinUser
+		orders []joinOrder
+		want   []string
+	}
+	tests := []testCase{
+		{
+			name:   "one to many",
+			users:  []joinUser{{1, "ann"}, {2, "bob"}, {4, "dan"}},
+			orders: []joinOrder{{1, "book"}, {1, "pen"}, {3, "cup"}, {4, "lamp"}, {5, "mug"}},
+			want:   []string{"ann:book", "ann:pen", "dan:lamp"},
+		},
+		{
+			name:   "many to many",
+			users:  []joinUser{{1, "ann"}, {1, "amy"}, {2, "bob"}},
+			orders: []joinOrder{{1, "book"}, {1, "pen"}, {2, "cup"}},
+			want:   []string{"ann:book", "ann:pen", "amy:book", "amy:pen", "bob:cup"},
+		},
+		{
+			name:   "no matches",
+			users:  []joinUser{{1, "ann"}},
+			orders: []joinOrder{{2, "cup"}},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for u, o := range MergeJoin(slices.Values(tt.users), slices.Values(tt.orders), userID, orderUser, cmp.Compare[int]) {
+				got = append(got, u.name+":"+o.item)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeJoin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeJoinStreams(t *testing.T) {
+	// Both sides are infinite, so only a streaming join can produce anything.
+	evens := Count(0, 2)
+	threes := Count(0, 3)
+	id := func(v int) int { return v }
+	keys := Keys(MergeJoin(evens, threes, id, id, cmp.Compare[int]))
+	if got, want := slices.Collect(Take(keys, 4)), []int{0, 6, 12, 18}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeJoin() = %v, want %v", got, want)
+	}
+
+	itertest.CheckNoGoroutineLeak(t, func() {
+		for range keys {
+			break
+		}
+	})
+}
@@ -0,0 +1,175 @@
+// Package jsonseq provides lazy sequences over JSON data
+// built on top of the encoding/json package.
+//
+// Decode supports newline-delimited JSON (NDJSON), or more generally any stream
+// of whitespace-separated JSON values, while DecodeArray iterates over
+// the elements of a top-level JSON array.
+package jsonseq
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// Error reports a failure to decode a value from the input.
+type Error struct {
+	Offset int64 // Byte offset in the input where the error was detected
+	Err    error // The underlying error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonseq: offset %d: %v", e.Offset, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Decode returns a sequence of values of type T decoded from `r`,
+// which holds a stream of whitespace-separated JSON values such as NDJSON.
+// Values are decoded as they are needed, so the whole input is never loaded
+// into memory, and no more input is read once the consumer stops the iteration.
+// A top-level JSON array is decoded as a single value; use DecodeArray
+// to iterate over its elements instead.
+//
+// Iteration stops after the first error is yielded. Errors are reported as *Error.
+//
+// Example:
+//
+//	type Event struct {
+//		ID int `json:"id"`
+//	}
+//	for e, err := range Decode[Event](strings.NewReader("{\"id\":1}\n{\"id\":2}\n")) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(e.ID) // 1, 2
+//	}
+func Decode[T any](r io.Reader) iter.Seq2[T, error] {
+	return decode[T](r, false)
+}
+
+// DecodeArray returns a sequence of the elements of type T of the JSON array read from `r`.
+// Like Decode, it decodes the elements one at a time as they are needed,
+// so the whole array is never loaded into memory.
+// An input that doesn't start with an array is reported as an error.
+//
+// Iteration stops after the first error is yielded. Errors are reported as *Error.
+//
+// Example:
+//
+//	for e, err := range DecodeArray[Event](strings.NewReader(`[{"id":1},{"id":2}]`)) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(e.ID) // 1, 2
+//	}
+func DecodeArray[T any](r io.Reader) iter.Seq2[T, error] {
+	return decode[T](r, true)
+}
+
+// errNotArray is reported by DecodeArray when the input doesn't start with an array.
+var errNotArray = errors.New("expected a JSON array")
+
+// decode is the core of Decode and DecodeArray.
+func decode[T any](r io.Reader, array bool) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		dec := json.NewDecoder(r)
+		fail := func(err error) {
+			yield(zero, &Error{Offset: dec.InputOffset(), Err: err})
+		}
+
+		if array {
+			tok, err := dec.Token()
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			if err != nil {
+				fail(err)
+				return
+			}
+			if tok != json.Delim('[') {
+				fail(errNotArray)
+				return
+			}
+		}
+
+		for {
+			if array && !dec.More() {
+				break
+			}
+			var v T
+			err := dec.Decode(&v)
+			if !array && errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				fail(err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+
+		if _, err := dec.Token(); err != nil {
+			fail(err)
+		}
+	}
+}
+
+// Encode writes every value of `iter` to `w` as newline-delimited JSON.
+// Iteration stops at the first error, which is returned.
+//
+// Example:
+//
+//	Encode(os.Stdout, slices.Values([]int{1, 2, 3})) // "1\n2\n3\n"
+func Encode[T any](w io.Writer, iter iter.Seq[T]) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for v := range iter {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// EncodeArray writes the values of `iter` to `w` as a single JSON array.
+// Values are encoded one at a time, so the array is never built in memory.
+// Iteration stops at the first error, which is returned.
+//
+// Example:
+//
+//	EncodeArray(os.Stdout, slices.Values([]int{1, 2, 3})) // "[1,2,3]"
+func EncodeArray[T any](w io.Writer, iter iter.Seq[T]) error {
+	bw := bufio.NewWriter(w)
+	if err := bw.WriteByte('['); err != nil {
+		return err
+	}
+	first := true
+	for v := range iter {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if !first {
+			if err := bw.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		first = false
+		if _, err := bw.Write(b); err != nil {
+			return err
+		}
+	}
+	if err := bw.WriteByte(']'); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
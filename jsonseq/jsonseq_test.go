@@ -0,0 +1,260 @@
+package jsonseq
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type event struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// endlessReader produces an infinite stream of NDJSON values.
+type endlessReader struct {
+	reads int
+}
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	r.reads++
+	line := `{"id":1,"name":"x"}` + "\n"
+	n := 0
+	for n+len(line) <= len(p) {
+		n += copy(p[n:], line)
+	}
+	return n, nil
+}
+
+func TestDecode(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  []event
+	}
+	tests := []testCase{
+		{
+			name:  "ndjson",
+			input: "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n",
+			want:  []event{{1, "a"}, {2, "b"}},
+		},
+		{
+			name:  "whitespace separated",
+			input: "  {\"id\":1,\"name\":\"a\"} {\"id\":2,\"name\":\"b\"}",
+			want:  []event{{1, "a"}, {2, "b"}},
+		},
+		{
+			name:  "empty input",
+			input: "\n",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []event
+			for e, err := range Decode[event](strings.NewReader(tt.input)) {
+				if err != nil {
+					t.Fatalf("Decode() error = %v", err)
+				}
+				got = append(got, e)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeArrayValues(t *testing.T) {
+	// Lines holding arrays are values of the stream, not an array to iterate over.
+	var got [][]int
+	for v, err := range Decode[[]int](strings.NewReader("[1,2]\n[3,4]\n")) {
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		got = append(got, v)
+	}
+	if want := [][]int{{1, 2}, {3, 4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeArray(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  []event
+	}
+	tests := []testCase{
+		{
+			name:  "array",
+			input: "  [{\"id\":1,\"name\":\"a\"},\n{\"id\":2,\"name\":\"b\"}]",
+			want:  []event{{1, "a"}, {2, "b"}},
+		},
+		{
+			name:  "empty array",
+			input: "[]",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []event
+			for e, err := range DecodeArray[event](strings.NewReader(tt.input)) {
+				if err != nil {
+					t.Fatalf("DecodeArray() error = %v", err)
+				}
+				got = append(got, e)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeArray() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	type testCase struct {
+		name       string
+		decode     func(io.Reader) iter.Seq2[event, error]
+		input      string
+		wantValues int
+		wantOffset int64
+		wantErr    error
+	}
+	tests := []testCase{
+		{
+			name:       "ndjson",
+			decode:     Decode[event],
+			input:      "{\"id\":1}\n{\"id\":\"x\"}\n",
+			wantValues: 1,
+			wantOffset: 19,
+		},
+		{
+			name:       "array",
+			decode:     DecodeArray[event],
+			input:      " [{\"id\":1}, {\"id\":",
+			wantValues: 1,
+			wantOffset: 10,
+		},
+		{
+			name:       "not an array",
+			decode:     DecodeArray[event],
+			input:      " {\"id\":1}",
+			wantValues: 0,
+			wantOffset: 2,
+			wantErr:    errNotArray,
+		},
+		{
+			name:       "empty input for an array",
+			decode:     DecodeArray[event],
+			input:      "",
+			wantValues: 0,
+			wantOffset: 0,
+			wantErr:    io.ErrUnexpectedEOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotErr error
+			n := 0
+			for _, err := range tt.decode(strings.NewReader(tt.input)) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				n++
+			}
+
+			var decodeErr *Error
+			if !errors.As(gotErr, &decodeErr) {
+				t.Fatalf("Decode() error = %v, want *Error", gotErr)
+			}
+			if tt.wantErr != nil && !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("Decode() error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if decodeErr.Offset != tt.wantOffset {
+				t.Errorf("Decode() error offset = %d, want %d", decodeErr.Offset, tt.wantOffset)
+			}
+			if n != tt.wantValues {
+				t.Errorf("Decode() yielded %d values before the error, want %d", n, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestDecodeStopsReading(t *testing.T) {
+	r := &endlessReader{}
+	n := 0
+	for _, err := range Decode[event](r) {
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if r.reads > 2 {
+		t.Errorf("Decode() read %d times after the consumer stopped", r.reads)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	events := []event{{1, "a"}, {2, "b"}}
+	var sb strings.Builder
+	if err := Encode(&sb, slices.Values(events)); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	want := "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n"
+	if got := sb.String(); got != want {
+		t.Errorf("Encode() = %q, want %q", got, want)
+	}
+}
+
+func TestEncodeArray(t *testing.T) {
+	type testCase struct {
+		name   string
+		events []event
+		want   string
+	}
+	tests := []testCase{
+		{
+			name:   "simple",
+			events: []event{{1, "a"}, {2, "b"}},
+			want:   "[{\"id\":1,\"name\":\"a\"},{\"id\":2,\"name\":\"b\"}]",
+		},
+		{
+			name:   "empty",
+			events: nil,
+			want:   "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := EncodeArray(&sb, slices.Values(tt.events)); err != nil {
+				t.Fatalf("EncodeArray() error = %v", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("EncodeArray() = %q, want %q", got, tt.want)
+			}
+
+			var decoded []event
+			for e, err := range DecodeArray[event](strings.NewReader(sb.String())) {
+				if err != nil {
+					t.Fatalf("DecodeArray() error = %v", err)
+				}
+				decoded = append(decoded, e)
+			}
+			if !reflect.DeepEqual(decoded, tt.events) {
+				t.Errorf("EncodeArray() round trip = %v, want %v", decoded, tt.events)
+			}
+		})
+	}
+}
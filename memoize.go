@@ -0,0 +1,160 @@
+package itertools
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// pair holds a key-value pair of a Seq2.
+type pair[K, V any] struct {
+	k K
+	v V
+}
+
+// pairs converts a Seq2 into a sequence of pairs.
+func pairs[K, V any](seq iter.Seq2[K, V]) iter.Seq[pair[K, V]] {
+	return func(yield func(pair[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(pair[K, V]{k, v}) {
+				return
+			}
+		}
+	}
+}
+
+// memo records the elements pulled from a sequence, so that they can be replayed.
+type memo[V any] struct {
+	mu   sync.Mutex
+	cond sync.Cond // signaled when a reader is done pulling
+	seq  iter.Seq[V]
+	next func() (V, bool)
+	stop func()
+	buf  []V
+	done bool
+	// pulling is set while a reader waits for the next element without holding mu.
+	pulling bool
+	limit   int
+	// overflowed is set once the sequence turns out to be longer than the limit.
+	overflowed bool
+}
+
+func newMemo[V any](seq iter.Seq[V], limit int) *memo[V] {
+	m := &memo[V]{seq: seq, limit: limit}
+	m.cond.L = &m.mu
+	return m
+}
+
+// at returns the element at index `i`, pulling it from the sequence if needed.
+// Only one reader pulls at a time: the others wait for it if they need a new element,
+// but read the recorded ones without waiting.
+func (m *memo[V]) at(i int) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i >= len(m.buf) {
+		if m.overflowed {
+			m.overflow()
+		}
+		if m.done {
+			var zero V
+			return zero, false
+		}
+		if m.pulling {
+			m.cond.Wait()
+			continue
+		}
+		if m.next == nil {
+			m.next, m.stop = iter.Pull(m.seq)
+		}
+		v, ok := m.pull()
+		if !ok {
+			m.done = true
+			m.stop()
+			continue
+		}
+		if m.limit >= 0 && len(m.buf) >= m.limit {
+			m.overflowed, m.done = true, true
+			m.stop()
+			m.overflow()
+		}
+		m.buf = append(m.buf, v)
+	}
+	return m.buf[i], true
+}
+
+// pull gets the next element of the sequence, releasing mu in the meantime.
+// It must be called with mu held, and returns with mu held even if the sequence panics.
+func (m *memo[V]) pull() (V, bool) {
+	m.pulling = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.pulling = false
+		m.cond.Broadcast()
+	}()
+	return m.next()
+}
+
+// overflow panics, reporting that the sequence is longer than the limit.
+func (m *memo[V]) overflow() {
+	panic(fmt.Sprintf("itertools: memoized sequence exceeds the limit of %d elements", m.limit))
+}
+
+func (m *memo[V]) all(yield func(V) bool) {
+	for i := 0; ; i++ {
+		v, ok := m.at(i)
+		if !ok || !yield(v) {
+			return
+		}
+	}
+}
+
+// Memoize returns a sequence that records the elements of `iter` as they are first produced
+// and replays them on later iterations, so `iter` itself is consumed at most once.
+//
+// The returned sequence is safe for concurrent use. Readers that get ahead of the others
+// pull new elements from `iter`, while the rest catch up from the recorded ones.
+//
+// Elements are pulled with iter.Pull. If `iter` is never exhausted,
+// the goroutine backing it is never released.
+//
+// Example:
+//
+//	ch := make(chan int, 3)
+//	ch <- 1; ch <- 2; ch <- 3
+//	close(ch)
+//	seq := Memoize(FromChan(ch))
+//	fmt.Println(slices.Collect(seq)) // [1 2 3]
+//	fmt.Println(slices.Collect(seq)) // [1 2 3]
+func Memoize[V any](iter iter.Seq[V]) iter.Seq[V] {
+	return MemoizeN(iter, -1)
+}
+
+// MemoizeN is like Memoize, but records at most `n` elements.
+// It panics if an iteration reaches past the first `n` elements.
+// A negative `n` means no limit.
+func MemoizeN[V any](iter iter.Seq[V], n int) iter.Seq[V] {
+	m := newMemo(iter, n)
+	return checked(m.all)
+}
+
+// Memoize2 returns a sequence that records the key-value pairs of `iter` as they are first produced
+// and replays them on later iterations. See Memoize for details.
+func Memoize2[K, V any](iter iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return Memoize2N(iter, -1)
+}
+
+// Memoize2N is like Memoize2, but records at most `n` pairs.
+// It panics if an iteration reaches past the first `n` pairs.
+// A negative `n` means no limit.
+func Memoize2N[K, V any](iter iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	m := newMemo(pairs(iter), n)
+	return checked2(func(yield func(K, V) bool) {
+		for p := range m.all {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package itertools
+
+import (
+	"iter"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingSeq returns a sequence of `elems` along with the number of times it was iterated.
+func countingSeq[V any](elems ...V) (iter.Seq[V], *int) {
+	runs := 0
+	return func(yield func(V) bool) {
+		runs++
+		for _, v := range elems {
+			if !yield(v) {
+				return
+			}
+		}
+	}, &runs
+}
+
+func TestMemoize(t *testing.T) {
+	seq, runs := countingSeq(1, 2, 3, 4)
+	memo := Memoize(seq)
+
+	if got, want := slices.Collect(Take(memo, 2)), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Memoize() partial = %v, want %v", got, want)
+	}
+	for range 2 {
+		if got, want := slices.Collect(memo), []int{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Memoize() = %v, want %v", got, want)
+		}
+	}
+	if *runs != 1 {
+		t.Errorf("Memoize() iterated the source %d times, want 1", *runs)
+	}
+}
+
+func TestMemoizeChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	memo := Memoize(FromChan(ch))
+	for range 2 {
+		if got, want := slices.Collect(memo), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Memoize() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		for i := range 100 {
+			ch <- i
+		}
+		close(ch)
+	}()
+	memo := Memoize(FromChan(ch))
+	want := slices.Collect(Take(Count(0, 1), 100))
+
+	var wg sync.WaitGroup
+	results := make([][]int, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = slices.Collect(memo)
+		}()
+	}
+	wg.Wait()
+
+	for i, got := range results {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Memoize() reader %d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestMemoizeReadsRecordedWhilePulling(t *testing.T) {
+	ch := make(chan int)
+	memo := Memoize(FromChan(ch))
+
+	pulled := make(chan []int)
+	go func() {
+		pulled <- slices.Collect(memo)
+	}()
+	// Once the first element is received, the reader goes on pulling the second one.
+	ch <- 1
+
+	read := make(chan int)
+	go func() {
+		for v := range memo {
+			read <- v
+			return
+		}
+	}()
+	select {
+	case v := <-read:
+		if v != 1 {
+			t.Errorf("Memoize() first element = %d, want 1", v)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Memoize() blocked reading a recorded element while another reader is pulling")
+	}
+
+	close(ch)
+	if got, want := <-pulled, []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Memoize() = %v, want %v", got, want)
+	}
+}
+
+func TestMemoizeN(t *testing.T) {
+	memo := MemoizeN(FromElements(1, 2, 3), 3)
+	if got, want := slices.Collect(memo), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MemoizeN() = %v, want %v", got, want)
+	}
+
+	overflowing := MemoizeN(FromElements(1, 2, 3), 2)
+	if got, want := slices.Collect(Take(overflowing, 2)), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MemoizeN() = %v, want %v", got, want)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic, but did not get one")
+		}
+	}()
+	for range overflowing {
+	}
+}
+
+func TestMemoizeNOverflowIsSticky(t *testing.T) {
+	memo := MemoizeN(FromElements(1, 2, 3), 2)
+	for i := range 2 {
+		if panicked, _ := panics(func() {
+			for range memo {
+			}
+		}); !panicked {
+			t.Errorf("MemoizeN() did not panic on iteration %d past the limit", i+1)
+		}
+	}
+
+	// The recorded elements can still be read without going past the limit.
+	if got, want := slices.Collect(Take(memo, 2)), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MemoizeN() = %v, want %v", got, want)
+	}
+}
+
+func TestMemoize2(t *testing.T) {
+	seq, runs := countingSeq("a", "b", "c")
+	memo := Memoize2(Enumerate(seq))
+	for range 2 {
+		keys, values := unwrapIterator2(memo, 3)
+		if want := []int{0, 1, 2}; !reflect.DeepEqual(keys, want) {
+			t.Errorf("Memoize2() keys = %v, want %v", keys, want)
+		}
+		if want := []string{"a", "b", "c"}; !reflect.DeepEqual(values, want) {
+			t.Errorf("Memoize2() values = %v, want %v", values, want)
+		}
+	}
+	if *runs != 1 {
+		t.Errorf("Memoize2() iterated the source %d times, want 1", *runs)
+	}
+}
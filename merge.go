@@ -0,0 +1,143 @@
+package itertools
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+)
+
+// head is the current element of one of the merged sequences.
+type head[V any] struct {
+	v    V
+	src  int
+	next func() (V, bool)
+}
+
+// heads is a min-heap of the current elements of the merged sequences.
+type heads[V any] struct {
+	items  []head[V]
+	cmp    func(V, V) int
+	stable bool
+}
+
+func (h *heads[V]) Len() int { return len(h.items) }
+func (h *heads[V]) Less(i, j int) bool {
+	c := h.cmp(h.items[i].v, h.items[j].v)
+	if c == 0 && h.stable {
+		return h.items[i].src < h.items[j].src
+	}
+	return c < 0
+}
+func (h *heads[V]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *heads[V]) Push(x any)    { h.items = append(h.items, x.(head[V])) }
+func (h *heads[V]) Pop() any {
+	x := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return x
+}
+
+// mergeSorted is the core of MergeSorted and its variants.
+func mergeSorted[V any](cmp func(V, V) int, stable bool, iters []iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		h := &heads[V]{items: make([]head[V], 0, len(iters)), cmp: cmp, stable: stable}
+		for i, seq := range iters {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			if v, ok := next(); ok {
+				h.items = append(h.items, head[V]{v, i, next})
+			}
+		}
+		heap.Init(h)
+
+		for h.Len() > 0 {
+			top := &h.items[0]
+			if !yield(top.v) {
+				return
+			}
+			if v, ok := top.next(); ok {
+				top.v = v
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+// MergeSorted returns a sequence of the elements of all `iters` in sorted order,
+// given that each of them is already sorted according to `cmp`.
+// The sequences are merged lazily, pulling one element at a time from each,
+// so they may be arbitrarily long. The order of equal elements coming from different
+// sequences is unspecified; use MergeSortedStable to keep it.
+//
+// `cmp` should return a negative number when a < b, a positive number
+// when a > b and zero when a == b, like cmp.Compare does.
+//
+// Example:
+//
+//	shards := []iter.Seq[int]{FromElements(1, 4, 7), FromElements(2, 5), FromElements(3, 6, 9)}
+//	for v := range MergeSorted(cmp.Compare[int], shards...) {
+//		fmt.Println(v) // 1, 2, 3, 4, 5, 6, 7, 9
+//	}
+func MergeSorted[V any](cmp func(V, V) int, iters ...iter.Seq[V]) iter.Seq[V] {
+	return checked(mergeSorted(cmp, false, iters))
+}
+
+// MergeSortedStable is like MergeSorted, but equal elements are yielded
+// in the order of the sequences they come from.
+//
+// Example:
+//
+//	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+//	merged := MergeSortedStable(byLen, FromElements("a", "bb"), FromElements("c", "dd"))
+//	for v := range merged {
+//		fmt.Println(v) // "a", "c", "bb", "dd"
+//	}
+func MergeSortedStable[V any](cmp func(V, V) int, iters ...iter.Seq[V]) iter.Seq[V] {
+	return checked(mergeSorted(cmp, true, iters))
+}
+
+// MergeSortedBy is like MergeSorted, but compares elements by the result of `key`,
+// given that each of the sequences is sorted by it.
+//
+// Example:
+//
+//	type event struct {
+//		at   int
+//		name string
+//	}
+//	a := FromElements(event{1, "start"}, event{5, "stop"})
+//	b := FromElements(event{3, "ping"})
+//	for e := range MergeSortedBy(func(e event) int { return e.at }, a, b) {
+//		fmt.Println(e.name) // "start", "ping", "stop"
+//	}
+func MergeSortedBy[V any, K cmp.Ordered](key func(V) K, iters ...iter.Seq[V]) iter.Seq[V] {
+	return MergeSorted(func(a, b V) int { return cmp.Compare(key(a), key(b)) }, iters...)
+}
+
+// MergeSorted2 returns a sequence of the key-value pairs of all `iters` ordered by key,
+// given that each of them is already sorted by key according to `cmp`.
+// See MergeSorted for details.
+//
+// Example:
+//
+//	a := Zip(FromElements(1, 3), FromElements("a", "c"))
+//	b := Zip(FromElements(2, 4), FromElements("b", "d"))
+//	for k, v := range MergeSorted2(cmp.Compare[int], a, b) {
+//		fmt.Println(k, v) // 1 "a", 2 "b", 3 "c", 4 "d"
+//	}
+func MergeSorted2[K, V any](cmp func(K, K) int, iters ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	seqs := make([]iter.Seq[pair[K, V]], len(iters))
+	for i, seq := range iters {
+		seqs[i] = pairs(seq)
+	}
+	merged := mergeSorted(func(a, b pair[K, V]) int { return cmp(a.k, b.k) }, false, seqs)
+
+	return checked2(func(yield func(K, V) bool) {
+		for p := range merged {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package itertools
+
+import (
+	"cmp"
+	"iter"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/dzherb/go-itertools/itertest"
+)
+
+func TestMergeSorted(t *testing.T) {
+	type testCase struct {
+		name  string
+		iters []iter.Seq[int]
+		want  []int
+	}
+	tests := []testCase{
+		{
+			name: "three shards",
+			iters: []iter.Seq[int]{
+				FromElements(1, 4, 7),
+				FromElements(2, 5),
+				FromElements(3, 6, 9),
+			},
+			want: []int{1, 2, 3, 4, 5, 6, 7, 9},
+		},
+		{
+			name: "duplicates and empty shards",
+			iters: []iter.Seq[int]{
+				FromElements[int](),
+				FromElements(1, 1, 3),
+				FromElements(1, 2),
+				FromElements[int](),
+			},
+			want: []int{1, 1, 1, 2, 3},
+		},
+		{
+			name:  "no shards",
+			iters: nil,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(MergeSorted(cmp.Compare[int], tt.iters...)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeSorted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeSortedInfinite(t *testing.T) {
+	evens, odds := Count(0, 2), Count(1, 2)
+	merged := MergeSorted(cmp.Compare[int], evens, odds)
+	if got, want := slices.Collect(Take(merged, 6)), []int{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeSorted() = %v, want %v", got, want)
+	}
+
+	itertest.CheckNoGoroutineLeak(t, func() {
+		for range merged {
+			break
+		}
+	})
+}
+
+func TestMergeSortedStable(t *testing.T) {
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	merged := MergeSortedStable(byLen,
+		FromElements("a", "bb", "ccc"),
+		FromElements("d", "ee"),
+		FromElements("f", "gg", "hhh"),
+	)
+	want := []string{"a", "d", "f", "bb", "ee", "gg", "ccc", "hhh"}
+	if got := slices.Collect(merged); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeSortedStable() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeSortedBy(t *testing.T) {
+	type event struct {
+		at   int
+		name string
+	}
+	a := FromElements(event{1, "start"}, event{5, "stop"})
+	b := FromElements(event{3, "ping"}, event{4, "pong"})
+	merged := MergeSortedBy(func(e event) int { return e.at }, a, b)
+	names := slices.Collect(Map(merged, func(e event) string { return e.name }))
+	if want := []string{"start", "ping", "pong", "stop"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("MergeSortedBy() = %v, want %v", names, want)
+	}
+}
+
+func TestMergeSorted2(t *testing.T) {
+	a := Zip(FromElements(1, 3), FromElements("a", "c"))
+	b := Zip(FromElements(2, 4, 5), FromElements("b", "d", "e"))
+	keys, values := unwrapIterator2(MergeSorted2(cmp.Compare[int], a, b), 10)
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("MergeSorted2() keys = %v, want %v", keys, want)
+	}
+	if want := []string{"a", "b", "c", "d", "e"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("MergeSorted2() values = %v, want %v", values, want)
+	}
+}
@@ -0,0 +1,144 @@
+package itertools
+
+import (
+	"iter"
+)
+
+// Integer is a constraint permitting any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint permitting any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint permitting any integer or floating-point type.
+type Number interface {
+	Integer | Float
+}
+
+// isFloat reports whether T is a floating-point type.
+func isFloat[T Number]() bool {
+	half := 0.5
+	return T(half) != 0
+}
+
+// CountOf returns an infinite sequence of numbers of any numeric type
+// starting from `start`, incremented by `step`.
+//
+// Floating-point elements are computed as start + i*step rather than
+// by repeated addition, so rounding errors don't accumulate.
+// Integer elements wrap around on overflow, like regular arithmetic does.
+//
+// Example:
+//
+//	count := CountOf(0.0, 0.1)
+//	for n := range Take(count, 4) {
+//		fmt.Println(n) // 0, 0.1, 0.2, 0.30000000000000004
+//	}
+func CountOf[T Number](start, step T) iter.Seq[T] {
+	float := isFloat[T]()
+	return checked(func(yield func(T) bool) {
+		cur := start
+		for i := 1; ; i++ {
+			if !yield(cur) {
+				return
+			}
+			if float {
+				cur = start + T(i)*step
+			} else {
+				cur += step
+			}
+		}
+	})
+}
+
+const rangePanicMessage = "itertools: step argument can't be zero"
+
+// Range returns a sequence of numbers from `start` up to, but not including, `stop`,
+// incremented by `step`. A negative `step` counts down, in which case
+// `stop` must be less than `start` for the sequence to be non-empty.
+//
+// The sequence ends instead of wrapping around if the next integer element
+// would overflow its type. Floating-point elements are computed
+// as start + i*step, so rounding errors don't accumulate.
+//
+// It panics if `step` is zero.
+//
+// Example:
+//
+//	for n := range Range[uint8](250, 255, 2) {
+//		fmt.Println(n) // 250, 252, 254
+//	}
+//	for n := range Range(10, 0, -3) {
+//		fmt.Println(n) // 10, 7, 4, 1
+//	}
+func Range[T Number](start, stop, step T) iter.Seq[T] {
+	if step == 0 {
+		panic(rangePanicMessage)
+	}
+	float := isFloat[T]()
+
+	return checked(func(yield func(T) bool) {
+		up := step > 0
+		cur := start
+		for i := 1; ; i++ {
+			if (up && cur >= stop) || (!up && cur <= stop) {
+				return
+			}
+			if !yield(cur) {
+				return
+			}
+
+			var next T
+			if float {
+				next = start + T(i)*step
+			} else {
+				next = cur + step
+				if (up && next < cur) || (!up && next > cur) {
+					return
+				}
+			}
+			cur = next
+		}
+	})
+}
+
+const linspacePanicMessage = "itertools: number of points can't be negative"
+
+// Linspace returns a sequence of `n` evenly spaced numbers over the interval
+// from `start` to `stop`, both included. Every element is computed
+// from the bounds directly, so the last one is exactly `stop`.
+//
+// It panics if `n` is negative.
+//
+// Example:
+//
+//	for x := range Linspace(0.0, 1.0, 5) {
+//		fmt.Println(x) // 0, 0.25, 0.5, 0.75, 1
+//	}
+func Linspace[T Float](start, stop T, n int) iter.Seq[T] {
+	if n < 0 {
+		panic(linspacePanicMessage)
+	}
+
+	return checked(func(yield func(T) bool) {
+		for i := range n {
+			var x T
+			switch i {
+			case 0:
+				x = start
+			case n - 1:
+				x = stop
+			default:
+				x = start + (stop-start)*T(i)/T(n-1)
+			}
+			if !yield(x) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package itertools
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCountOf(t *testing.T) {
+	if got, want := slices.Collect(Take(CountOf[int64](-2, 3), 4)), []int64{-2, 1, 4, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountOf[int64]() = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(Take(CountOf[uint8](254, 1), 3)), []uint8{254, 255, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountOf[uint8]() = %v, want %v", got, want)
+	}
+
+	step := 0.1
+	got := slices.Collect(Take(CountOf(0.0, step), 1001))
+	if last := got[len(got)-1]; last != 1000*step {
+		t.Errorf("CountOf[float64]() accumulated rounding error: %v", last)
+	}
+}
+
+func TestRange(t *testing.T) {
+	type testCase struct {
+		name  string
+		start int8
+		stop  int8
+		step  int8
+		want  []int8
+	}
+	tests := []testCase{
+		{
+			name:  "ascending",
+			start: 0,
+			stop:  5,
+			step:  2,
+			want:  []int8{0, 2, 4},
+		},
+		{
+			name:  "descending",
+			start: 10,
+			stop:  0,
+			step:  -3,
+			want:  []int8{10, 7, 4, 1},
+		},
+		{
+			name:  "empty",
+			start: 5,
+			stop:  0,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "up to the maximum",
+			start: 120,
+			stop:  math.MaxInt8,
+			step:  5,
+			want:  []int8{120, 125},
+		},
+		{
+			name:  "down to the minimum",
+			start: -120,
+			stop:  math.MinInt8,
+			step:  -5,
+			want:  []int8{-120, -125},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Range(tt.start, tt.stop, tt.step)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Range() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeOverflow(t *testing.T) {
+	if got, want := slices.Collect(Range[uint8](250, 255, 2)), []uint8{250, 252, 254}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Range[uint8]() = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(Range[uint8](3, 0, 2)), []uint8(nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("Range[uint8]() = %v, want %v", got, want)
+	}
+}
+
+func TestRangeFloat(t *testing.T) {
+	step := 0.1
+	got := slices.Collect(Range(0.0, 1.0, step))
+	if len(got) != 10 {
+		t.Errorf("Range[float64]() = %v, want 10 elements", got)
+	}
+	if got[7] != 7*step {
+		t.Errorf("Range[float64]() element 7 = %v, want %v", got[7], 7*step)
+	}
+}
+
+func TestRangePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic, but did not get one")
+		}
+	}()
+	Range(0, 10, 0)
+}
+
+func TestLinspace(t *testing.T) {
+	type testCase struct {
+		name  string
+		start float64
+		stop  float64
+		n     int
+		want  []float64
+	}
+	tests := []testCase{
+		{
+			name:  "five points",
+			start: 0,
+			stop:  1,
+			n:     5,
+			want:  []float64{0, 0.25, 0.5, 0.75, 1},
+		},
+		{
+			name:  "descending",
+			start: 1,
+			stop:  -1,
+			n:     3,
+			want:  []float64{1, 0, -1},
+		},
+		{
+			name:  "single point",
+			start: 2,
+			stop:  3,
+			n:     1,
+			want:  []float64{2},
+		},
+		{
+			name:  "no points",
+			start: 2,
+			stop:  3,
+			n:     0,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Linspace(tt.start, tt.stop, tt.n)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Linspace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	got := slices.Collect(Linspace(0.0, 0.3, 4))
+	if got[len(got)-1] != 0.3 {
+		t.Errorf("Linspace() last element = %v, want exactly 0.3", got[len(got)-1])
+	}
+}
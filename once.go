@@ -0,0 +1,150 @@
+package itertools
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"runtime/debug"
+	"sync"
+)
+
+// ErrConsumed is reported when a single-use sequence is iterated more than once.
+var ErrConsumed = errors.New("itertools: iterator can only be consumed once")
+
+// ReuseError describes an attempt to iterate a single-use sequence more than once.
+// It matches ErrConsumed with errors.Is.
+type ReuseError struct {
+	// Stack is the stack trace of the first consumption,
+	// recorded only if OnceOptions.Debug is set.
+	Stack []byte
+}
+
+func (e *ReuseError) Error() string {
+	if e.Stack == nil {
+		return ErrConsumed.Error()
+	}
+	return fmt.Sprintf("%v; first consumed at:\n%s", ErrConsumed, e.Stack)
+}
+
+func (e *ReuseError) Is(target error) bool {
+	return target == ErrConsumed
+}
+
+// ReusePolicy determines what happens when a single-use sequence is iterated again.
+type ReusePolicy int
+
+const (
+	// ReusePanic panics with a *ReuseError.
+	ReusePanic ReusePolicy = iota
+	// ReuseEmpty silently yields nothing.
+	ReuseEmpty
+	// ReuseReport yields nothing and passes a *ReuseError to OnceOptions.OnReuse.
+	ReuseReport
+)
+
+// OnceOptions configures OnceWith and Once2With.
+// The zero value panics on reuse, like Once does.
+type OnceOptions struct {
+	// Policy determines what happens on reuse.
+	Policy ReusePolicy
+	// OnReuse receives the error when Policy is ReuseReport.
+	OnReuse func(error)
+	// Debug records the stack trace of the first consumption,
+	// so the reuse error tells where the sequence was already used.
+	Debug bool
+}
+
+// onceGuard tracks the consumption of a single-use sequence.
+type onceGuard struct {
+	mu       sync.Mutex
+	opts     OnceOptions
+	consumed bool
+	stack    []byte
+}
+
+// acquire reports whether the sequence may be consumed,
+// applying the reuse policy if it already was.
+func (g *onceGuard) acquire() bool {
+	g.mu.Lock()
+	if !g.consumed {
+		g.consumed = true
+		if g.opts.Debug {
+			g.stack = debug.Stack()
+		}
+		g.mu.Unlock()
+		return true
+	}
+	err := &ReuseError{Stack: g.stack}
+	g.mu.Unlock()
+
+	switch g.opts.Policy {
+	case ReuseEmpty:
+	case ReuseReport:
+		if g.opts.OnReuse != nil {
+			g.opts.OnReuse(err)
+		}
+	default:
+		panic(err)
+	}
+	return false
+}
+
+// OnceWith ensures that the given iterator can only be consumed once,
+// handling further attempts according to `opts`.
+//
+// Example:
+//
+//	seq := OnceWith(FromElements(1, 2, 3), OnceOptions{
+//		Policy:  ReuseReport,
+//		OnReuse: func(err error) { log.Print(err) },
+//		Debug:   true,
+//	})
+//	for v := range seq {
+//		fmt.Println(v) // 1, 2, 3
+//	}
+//	for v := range seq {
+//		fmt.Println(v) // never reached, the error is logged along with the stack trace of the first loop
+//	}
+func OnceWith[V any](iter iter.Seq[V], opts OnceOptions) iter.Seq[V] {
+	g := &onceGuard{opts: opts}
+	return checked(func(yield func(V) bool) {
+		if !g.acquire() {
+			return
+		}
+		for v := range iter {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Once2 ensures that the given key-value iterator can only be consumed once.
+// It panics if an attempt to iterate over the sequence a second time is made.
+// Use Once2With to handle reuse differently.
+//
+// Example:
+//
+//	seq := Once2(Enumerate(FromElements("a", "b")))
+//	for i, v := range seq {
+//		fmt.Println(i, v) // 0 "a", 1 "b"
+//	}
+func Once2[K, V any](iter iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return Once2With(iter, OnceOptions{})
+}
+
+// Once2With ensures that the given key-value iterator can only be consumed once,
+// handling further attempts according to `opts`.
+func Once2With[K, V any](iter iter.Seq2[K, V], opts OnceOptions) iter.Seq2[K, V] {
+	g := &onceGuard{opts: opts}
+	return checked2(func(yield func(K, V) bool) {
+		if !g.acquire() {
+			return
+		}
+		for k, v := range iter {
+			if !yield(k, v) {
+				return
+			}
+		}
+	})
+}
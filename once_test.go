@@ -0,0 +1,94 @@
+package itertools
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOnce2(t *testing.T) {
+	seq := Once2(Enumerate(FromElements("a", "b")))
+
+	keys, values := unwrapIterator2(seq, 2)
+	if want := []int{0, 1}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Once2() keys = %v, want %v", keys, want)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Once2() values = %v, want %v", values, want)
+	}
+
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrConsumed) {
+			t.Errorf("Once2() panicked with %v, want %v", r, ErrConsumed)
+		}
+	}()
+	for k, v := range seq {
+		t.Errorf("Iterator should have panicked before reaching pair: %v %v", k, v)
+	}
+}
+
+func TestOnceWith(t *testing.T) {
+	type testCase struct {
+		name       string
+		policy     ReusePolicy
+		wantReport bool
+	}
+	tests := []testCase{
+		{
+			name:       "empty",
+			policy:     ReuseEmpty,
+			wantReport: false,
+		},
+		{
+			name:       "report",
+			policy:     ReuseReport,
+			wantReport: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported error
+			seq := OnceWith(FromElements(1, 2, 3), OnceOptions{
+				Policy:  tt.policy,
+				OnReuse: func(err error) { reported = err },
+			})
+
+			if got, want := slices.Collect(seq), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+				t.Errorf("OnceWith() = %v, want %v", got, want)
+			}
+			if got := slices.Collect(seq); len(got) != 0 {
+				t.Errorf("OnceWith() on reuse = %v, want nothing", got)
+			}
+			if got := errors.Is(reported, ErrConsumed); got != tt.wantReport {
+				t.Errorf("OnceWith() reported %v, want report %v", reported, tt.wantReport)
+			}
+		})
+	}
+}
+
+func TestOnceWithDebug(t *testing.T) {
+	var reported error
+	seq := OnceWith(FromElements(1), OnceOptions{
+		Policy:  ReuseReport,
+		OnReuse: func(err error) { reported = err },
+		Debug:   true,
+	})
+	consumeFirst := func() {
+		for range seq {
+		}
+	}
+	consumeFirst()
+	for range seq {
+	}
+
+	var reuseErr *ReuseError
+	if !errors.As(reported, &reuseErr) {
+		t.Fatalf("OnceWith() reported %v, want *ReuseError", reported)
+	}
+	if !strings.Contains(reuseErr.Error(), "TestOnceWithDebug.func") {
+		t.Errorf("OnceWith() error does not point to the first consumption:\n%v", reuseErr)
+	}
+}
@@ -0,0 +1,114 @@
+package itertools
+
+import (
+	"context"
+	"iter"
+)
+
+// PageFunc fetches the page identified by `token`. It returns the items of the page
+// and the token of the next page, which is the zero value on the last page.
+// The first page is requested with the zero token.
+type PageFunc[V any, T comparable] func(ctx context.Context, token T) (items []V, next T, err error)
+
+// PaginateOptions configures Paginate. The zero value fetches pages
+// one at a time, when they are needed, without retrying.
+type PaginateOptions struct {
+	// Prefetch makes the next page be fetched in the background
+	// while the items of the current one are consumed.
+	Prefetch bool
+	// Retry is applied to every page fetch.
+	Retry RetryPolicy
+}
+
+type page[V any, T comparable] struct {
+	items []V
+	next  T
+	err   error
+}
+
+// Paginate returns a flat sequence of the items of a paged source.
+// Pages are fetched lazily with `fetch`, and no more pages are fetched
+// once the consumer stops the iteration or `ctx` is done.
+//
+// An error that can't be retried is yielded, after which the iteration stops.
+//
+// Example:
+//
+//	fetch := func(ctx context.Context, cursor string) ([]User, string, error) {
+//		resp, err := client.ListUsers(ctx, cursor)
+//		if err != nil {
+//			return nil, "", err
+//		}
+//		return resp.Users, resp.NextCursor, nil
+//	}
+//	for u, err := range Paginate(ctx, fetch, PaginateOptions{Prefetch: true}) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(u.Name)
+//	}
+func Paginate[V any, T comparable](ctx context.Context, fetch PageFunc[V, T], opts PaginateOptions) iter.Seq2[V, error] {
+	return checked2(func(yield func(V, error) bool) {
+		var zero V
+		var end T
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		fetchPage := func(token T) page[V, T] {
+			var p page[V, T]
+			p.err = opts.Retry.do(ctx, func() error {
+				var err error
+				p.items, p.next, err = fetch(ctx, token)
+				return err
+			})
+			return p
+		}
+
+		// pending delivers the page being fetched in the background, if any.
+		var pending chan page[V, T]
+		prefetch := func(token T) {
+			pending = make(chan page[V, T], 1)
+			go func(ch chan<- page[V, T]) {
+				ch <- fetchPage(token)
+			}(pending)
+		}
+		defer func() {
+			if pending != nil {
+				cancel()
+				<-pending
+			}
+		}()
+
+		var token T
+		if opts.Prefetch {
+			prefetch(token)
+		}
+		for {
+			var p page[V, T]
+			if opts.Prefetch {
+				p = <-pending
+				pending = nil
+			} else {
+				p = fetchPage(token)
+			}
+			if p.err != nil {
+				yield(zero, p.err)
+				return
+			}
+			if opts.Prefetch && p.next != end {
+				prefetch(p.next)
+			}
+
+			for _, v := range p.items {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			if p.next == end {
+				return
+			}
+			token = p.next
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package itertools
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+// fakePager serves pages of consecutive integers, using page numbers as tokens.
+type fakePager struct {
+	pages    int
+	pageSize int
+	failures map[int]int // page -> number of transient failures left
+	fetches  atomic.Int64
+}
+
+func (p *fakePager) fetch(ctx context.Context, token int) ([]int, int, error) {
+	p.fetches.Add(1)
+	if p.failures[token] > 0 {
+		p.failures[token]--
+		return nil, 0, errTransient
+	}
+	items := make([]int, p.pageSize)
+	for i := range items {
+		items[i] = token*p.pageSize + i
+	}
+	next := token + 1
+	if next == p.pages {
+		next = 0
+	}
+	return items, next, nil
+}
+
+func TestPaginate(t *testing.T) {
+	type testCase struct {
+		name string
+		opts PaginateOptions
+	}
+	tests := []testCase{
+		{
+			name: "on demand",
+			opts: PaginateOptions{},
+		},
+		{
+			name: "prefetch",
+			opts: PaginateOptions{Prefetch: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pager := &fakePager{pages: 3, pageSize: 2}
+			var got []int
+			for v, err := range Paginate(context.Background(), pager.fetch, tt.opts) {
+				if err != nil {
+					t.Fatalf("Paginate() error = %v", err)
+				}
+				got = append(got, v)
+			}
+			want := []int{0, 1, 2, 3, 4, 5}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Paginate() = %v, want %v", got, want)
+			}
+			if n := pager.fetches.Load(); n != 3 {
+				t.Errorf("Paginate() fetched %d pages, want 3", n)
+			}
+		})
+	}
+}
+
+func TestPaginateBreak(t *testing.T) {
+	type testCase struct {
+		name        string
+		opts        PaginateOptions
+		wantFetches int64
+	}
+	tests := []testCase{
+		{
+			name:        "on demand",
+			opts:        PaginateOptions{},
+			wantFetches: 2,
+		},
+		{
+			name:        "prefetch",
+			opts:        PaginateOptions{Prefetch: true},
+			wantFetches: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pager := &fakePager{pages: 100, pageSize: 2}
+			n := 0
+			for _, err := range Paginate(context.Background(), pager.fetch, tt.opts) {
+				if err != nil {
+					t.Fatalf("Paginate() error = %v", err)
+				}
+				n++
+				if n == 3 {
+					break
+				}
+			}
+			if got := pager.fetches.Load(); got != tt.wantFetches {
+				t.Errorf("Paginate() fetched %d pages, want %d", got, tt.wantFetches)
+			}
+		})
+	}
+}
+
+func TestPaginateRetry(t *testing.T) {
+	type testCase struct {
+		name     string
+		failures map[int]int
+		policy   RetryPolicy
+		want     []int
+		wantErr  error
+	}
+	tests := []testCase{
+		{
+			name:     "recovers",
+			failures: map[int]int{1: 2},
+			policy:   RetryPolicy{MaxRetries: 3, InitialDelay: time.Millisecond},
+			want:     []int{0, 1, 2, 3},
+		},
+		{
+			name:     "gives up",
+			failures: map[int]int{1: 5},
+			policy:   RetryPolicy{MaxRetries: 3, InitialDelay: time.Millisecond},
+			want:     []int{0, 1},
+			wantErr:  errTransient,
+		},
+		{
+			name:     "not retryable",
+			failures: map[int]int{1: 1},
+			policy: RetryPolicy{
+				MaxRetries:   3,
+				InitialDelay: time.Millisecond,
+				Retryable:    func(err error) bool { return false },
+			},
+			want:    []int{0, 1},
+			wantErr: errTransient,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pager := &fakePager{pages: 2, pageSize: 2, failures: tt.failures}
+			opts := PaginateOptions{Prefetch: true, Retry: tt.policy}
+			var got []int
+			var gotErr error
+			for v, err := range Paginate(context.Background(), pager.fetch, opts) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				got = append(got, v)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Paginate() = %v, want %v", got, tt.want)
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("Paginate() error = %v, want %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
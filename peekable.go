@@ -0,0 +1,136 @@
+package itertools
+
+import (
+	"iter"
+)
+
+// Peekable wraps a sequence, allowing to look ahead at upcoming elements
+// and to push elements back, which is handy when writing parsers.
+//
+// It pulls elements from the sequence with iter.Pull, so Stop must be called
+// once the Peekable is no longer needed, unless the sequence has been exhausted.
+// A Peekable is not safe for concurrent use.
+type Peekable[V any] struct {
+	next func() (V, bool)
+	stop func()
+	// pushed holds the elements pushed back, the upcoming one last.
+	pushed []V
+	// ahead holds the elements already pulled, the upcoming one first.
+	ahead []V
+	done  bool
+}
+
+// NewPeekable returns a Peekable reading from `seq`.
+//
+// Example:
+//
+//	p := NewPeekable(FromElements(1, 2, 3))
+//	defer p.Stop()
+//	v, _ := p.Peek() // 1
+//	v, _ = p.Next()  // 1
+//	v, _ = p.Next()  // 2
+//	p.Unread(v)
+//	v, _ = p.Next()  // 2
+func NewPeekable[V any](seq iter.Seq[V]) *Peekable[V] {
+	next, stop := iter.Pull(seq)
+	return &Peekable[V]{next: next, stop: stop}
+}
+
+// fill makes sure at least `n` elements are buffered, if the sequence has that many.
+func (p *Peekable[V]) fill(n int) {
+	for len(p.pushed)+len(p.ahead) < n && !p.done {
+		v, ok := p.next()
+		if !ok {
+			p.Stop()
+			return
+		}
+		p.ahead = append(p.ahead, v)
+	}
+}
+
+// Peek returns the upcoming element without consuming it.
+// The boolean is false if the sequence is exhausted.
+func (p *Peekable[V]) Peek() (V, bool) {
+	p.fill(1)
+	if n := len(p.pushed); n > 0 {
+		return p.pushed[n-1], true
+	}
+	if len(p.ahead) > 0 {
+		return p.ahead[0], true
+	}
+	var zero V
+	return zero, false
+}
+
+// PeekN returns up to `n` upcoming elements without consuming them.
+// Fewer elements are returned if the sequence ends earlier.
+//
+// It panics if `n` is negative.
+func (p *Peekable[V]) PeekN(n int) []V {
+	if n < 0 {
+		panic(countPanicMessage)
+	}
+	p.fill(n)
+	out := make([]V, 0, n)
+	for i := len(p.pushed) - 1; i >= 0 && len(out) < n; i-- {
+		out = append(out, p.pushed[i])
+	}
+	for i := 0; i < len(p.ahead) && len(out) < n; i++ {
+		out = append(out, p.ahead[i])
+	}
+	return out
+}
+
+// Next consumes and returns the upcoming element.
+// The boolean is false if the sequence is exhausted.
+func (p *Peekable[V]) Next() (V, bool) {
+	v, ok := p.Peek()
+	switch {
+	case !ok:
+	case len(p.pushed) > 0:
+		p.pushed = p.pushed[:len(p.pushed)-1]
+	default:
+		p.ahead = p.ahead[1:]
+	}
+	return v, ok
+}
+
+// Unread pushes `v` back, making it the upcoming element.
+// Any number of elements can be pushed back, and they are
+// returned in the reverse order they were pushed in.
+func (p *Peekable[V]) Unread(v V) {
+	p.pushed = append(p.pushed, v)
+}
+
+// Stop releases the resources held by the underlying sequence.
+// Pushed back and already buffered elements remain available.
+// It is safe to call Stop multiple times.
+func (p *Peekable[V]) Stop() {
+	p.done = true
+	p.stop()
+}
+
+// All returns a sequence of the remaining elements,
+// continuing from the current position. The elements
+// consumed by the sequence are consumed from the Peekable as well.
+//
+// Example:
+//
+//	p := NewPeekable(FromElements("#", "a", "b"))
+//	defer p.Stop()
+//	if v, _ := p.Peek(); v == "#" {
+//		p.Next()
+//	}
+//	for v := range p.All() {
+//		fmt.Println(v) // "a", "b"
+//	}
+func (p *Peekable[V]) All() iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		for {
+			v, ok := p.Next()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package itertools
+
+import (
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPeekable(t *testing.T) {
+	p := NewPeekable(FromElements(1, 2, 3, 4))
+	defer p.Stop()
+
+	if v, ok := p.Peek(); !ok || v != 1 {
+		t.Errorf("Peek() = %v, %v, want 1, true", v, ok)
+	}
+	if got, want := p.PeekN(3), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PeekN(3) = %v, want %v", got, want)
+	}
+	if v, ok := p.Next(); !ok || v != 1 {
+		t.Errorf("Next() = %v, %v, want 1, true", v, ok)
+	}
+
+	p.Unread(10)
+	p.Unread(20)
+	if got, want := p.PeekN(4), []int{20, 10, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PeekN(4) = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(p.All()), []int{20, 10, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+
+	if v, ok := p.Next(); ok {
+		t.Errorf("Next() = %v, %v on an exhausted sequence", v, ok)
+	}
+	if got := p.PeekN(2); len(got) != 0 {
+		t.Errorf("PeekN(2) = %v on an exhausted sequence", got)
+	}
+}
+
+func TestPeekNBounds(t *testing.T) {
+	p := NewPeekable(FromElements(1, 2))
+	defer p.Stop()
+
+	if got := p.PeekN(0); len(got) != 0 {
+		t.Errorf("PeekN(0) = %v, want []", got)
+	}
+	if panicked, msg := panics(func() { p.PeekN(-1) }); !panicked || msg != countPanicMessage {
+		t.Errorf("PeekN(-1) panicked = %v with %q, want %q", panicked, msg, countPanicMessage)
+	}
+	if got, want := p.PeekN(5), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PeekN(5) = %v, want %v", got, want)
+	}
+}
+
+func TestPeekableAllContinues(t *testing.T) {
+	p := NewPeekable(FromElements("#", "a", "b", "c"))
+	defer p.Stop()
+
+	if v, _ := p.Peek(); v == "#" {
+		p.Next()
+	}
+	got := slices.Collect(Take(p.All(), 2))
+	if want := []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if v, ok := p.Next(); !ok || v != "c" {
+		t.Errorf("Next() = %v, %v, want \"c\", true", v, ok)
+	}
+}
+
+func TestPeekableStop(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	p := NewPeekable(Count(0, 1))
+	if got, want := p.PeekN(2), []int{0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PeekN(2) = %v, want %v", got, want)
+	}
+	p.Stop()
+	p.Stop()
+
+	if got, want := slices.Collect(p.All()), []int{0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("All() after Stop() = %v, want %v", got, want)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("Stop() left %d goroutines running", n-before)
+	}
+}
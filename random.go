@@ -0,0 +1,168 @@
+package itertools
+
+import (
+	"container/heap"
+	"iter"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// Random returns an infinite sequence of values produced by calling `gen` with `rng`.
+// Sequences built from generators seeded the same way produce the same values.
+//
+// Example:
+//
+//	rng := rand.New(rand.NewPCG(1, 2))
+//	dice := Random(rng, func(r *rand.Rand) int { return r.IntN(6) + 1 })
+//	for n := range Take(dice, 3) {
+//		fmt.Println(n) // the same three rolls on every run
+//	}
+func Random[V any](rng *rand.Rand, gen func(*rand.Rand) V) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		for yield(gen(rng)) {
+		}
+	})
+}
+
+// Shuffle returns a sequence of the elements of `iter` in a random order chosen by `rng`.
+// The whole of `iter` is collected before the first element is yielded,
+// and every iteration yields a new permutation.
+//
+// Example:
+//
+//	rng := rand.New(rand.NewPCG(1, 2))
+//	for v := range Shuffle(FromElements(1, 2, 3, 4), rng) {
+//		fmt.Println(v) // e.g. 3, 1, 4, 2
+//	}
+func Shuffle[V any](iter iter.Seq[V], rng *rand.Rand) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		s := slices.Collect(iter)
+		rng.Shuffle(len(s), func(i, j int) {
+			s[i], s[j] = s[j], s[i]
+		})
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// sampleInitialCap bounds the memory reserved for a sample before reading the input,
+// since `k` may be much larger than the number of elements.
+const sampleInitialCap = 64
+
+// Sample returns a sequence of `k` elements chosen uniformly at random from `iter`,
+// or all of them if `iter` has fewer than `k` elements.
+// It makes a single pass over `iter` keeping at most `k` elements in memory,
+// so it suits inputs of unknown length. The sample isn't in the original order.
+//
+// It panics if `k` is negative.
+//
+// Example:
+//
+//	rng := rand.New(rand.NewPCG(1, 2))
+//	for v := range Sample(Range(0, 1_000_000, 1), 3, rng) {
+//		fmt.Println(v) // e.g. 271828, 31415, 577215
+//	}
+func Sample[V any](iter iter.Seq[V], k int, rng *rand.Rand) iter.Seq[V] {
+	if k < 0 {
+		panic(countPanicMessage)
+	}
+
+	return checked(func(yield func(V) bool) {
+		if k == 0 {
+			return
+		}
+		reservoir := make([]V, 0, min(k, sampleInitialCap))
+		seen := 0
+		for v := range iter {
+			seen++
+			if len(reservoir) < k {
+				reservoir = append(reservoir, v)
+			} else if j := rng.IntN(seen); j < k {
+				reservoir[j] = v
+			}
+		}
+		for _, v := range reservoir {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// weighted is an element of a weighted sample along with its random key.
+type weighted[V any] struct {
+	v   V
+	key float64
+}
+
+// weightedHeap is a min-heap of weighted elements ordered by key.
+type weightedHeap[V any] []weighted[V]
+
+func (h weightedHeap[V]) Len() int           { return len(h) }
+func (h weightedHeap[V]) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h weightedHeap[V]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *weightedHeap[V]) Push(x any)        { *h = append(*h, x.(weighted[V])) }
+func (h *weightedHeap[V]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// WeightedSample returns a sequence of `k` distinct elements of `iter` chosen at random,
+// where the chance of an element to be chosen is proportional to `weight` of it.
+// Elements with a weight that isn't positive are never chosen.
+// Like Sample, it makes a single pass over `iter` keeping at most `k` elements in memory.
+// The chosen elements are yielded in the order of their random keys, highest first.
+//
+// It panics if `k` is negative.
+//
+// Example:
+//
+//	rng := rand.New(rand.NewPCG(1, 2))
+//	weights := map[string]float64{"common": 10, "rare": 1, "never": 0}
+//	seq := WeightedSample(maps.Keys(weights), 1, func(s string) float64 { return weights[s] }, rng)
+//	for v := range seq {
+//		fmt.Println(v) // "common" most of the time
+//	}
+func WeightedSample[V any](iter iter.Seq[V], k int, weight func(V) float64, rng *rand.Rand) iter.Seq[V] {
+	if k < 0 {
+		panic(countPanicMessage)
+	}
+
+	return checked(func(yield func(V) bool) {
+		if k == 0 {
+			return
+		}
+		// Every element gets the key u^(1/w) for a uniform u,
+		// and the elements with the k largest keys form the sample.
+		h := make(weightedHeap[V], 0, min(k, sampleInitialCap))
+		for v := range iter {
+			w := weight(v)
+			if !(w > 0) {
+				continue
+			}
+			key := math.Pow(rng.Float64(), 1/w)
+			if len(h) < k {
+				heap.Push(&h, weighted[V]{v, key})
+			} else if key > h[0].key {
+				h[0] = weighted[V]{v, key}
+				heap.Fix(&h, 0)
+			}
+		}
+
+		sample := make([]V, len(h))
+		for i := len(h) - 1; i >= 0; i-- {
+			sample[i] = heap.Pop(&h).(weighted[V]).v
+		}
+		for _, v := range sample {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
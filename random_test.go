@@ -0,0 +1,150 @@
+package itertools
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func newRand() *rand.Rand {
+	return rand.New(rand.NewPCG(1, 2))
+}
+
+func TestRandom(t *testing.T) {
+	gen := func(r *rand.Rand) int { return r.IntN(100) }
+	first := slices.Collect(Take(Random(newRand(), gen), 10))
+	second := slices.Collect(Take(Random(newRand(), gen), 10))
+	if len(first) != 10 {
+		t.Fatalf("Random() produced %d values, want 10", len(first))
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Random() = %v and %v with the same seed", first, second)
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	got := slices.Collect(Shuffle(slices.Values(input), newRand()))
+	if again := slices.Collect(Shuffle(slices.Values(input), newRand())); !reflect.DeepEqual(got, again) {
+		t.Errorf("Shuffle() = %v and %v with the same seed", got, again)
+	}
+
+	sorted := slices.Clone(got)
+	slices.Sort(sorted)
+	if !reflect.DeepEqual(sorted, input) {
+		t.Errorf("Shuffle() = %v, want a permutation of %v", got, input)
+	}
+	if reflect.DeepEqual(got, input) {
+		t.Errorf("Shuffle() = %v, want a different order", got)
+	}
+
+	if got := slices.Collect(Shuffle(FromElements[int](), newRand())); got != nil {
+		t.Errorf("Shuffle() = %v, want []", got)
+	}
+}
+
+func TestSample(t *testing.T) {
+	type testCase struct {
+		name    string
+		n       int
+		k       int
+		wantLen int
+	}
+	tests := []testCase{
+		{
+			name:    "3 of 100",
+			n:       100,
+			k:       3,
+			wantLen: 3,
+		},
+		{
+			name:    "more than available",
+			n:       4,
+			k:       10,
+			wantLen: 4,
+		},
+		{
+			name:    "zero",
+			n:       4,
+			k:       0,
+			wantLen: 0,
+		},
+		{
+			name:    "huge k",
+			n:       4,
+			k:       math.MaxInt / 2,
+			wantLen: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(Sample(Range(0, tt.n, 1), tt.k, newRand()))
+			if len(got) != tt.wantLen {
+				t.Fatalf("Sample() = %v, want %d elements", got, tt.wantLen)
+			}
+			seen := map[int]bool{}
+			for _, v := range got {
+				if v < 0 || v >= tt.n || seen[v] {
+					t.Fatalf("Sample() = %v, want distinct elements of the input", got)
+				}
+				seen[v] = true
+			}
+			if again := slices.Collect(Sample(Range(0, tt.n, 1), tt.k, newRand())); !reflect.DeepEqual(got, again) {
+				t.Errorf("Sample() = %v and %v with the same seed", got, again)
+			}
+		})
+	}
+}
+
+func TestSampleIsUniform(t *testing.T) {
+	const n, k, trials = 10, 3, 20000
+	rng := newRand()
+	counts := make([]int, n)
+	for range trials {
+		for v := range Sample(Range(0, n, 1), k, rng) {
+			counts[v]++
+		}
+	}
+	want := trials * k / n
+	for v, c := range counts {
+		if c < want*9/10 || c > want*11/10 {
+			t.Errorf("Sample() chose %d %d times, want about %d", v, c, want)
+		}
+	}
+}
+
+func TestWeightedSample(t *testing.T) {
+	weights := map[string]float64{"a": 8, "b": 1, "c": 1, "never": 0, "negative": -1}
+	weight := func(s string) float64 { return weights[s] }
+	input := []string{"a", "b", "never", "c", "negative"}
+
+	got := slices.Collect(WeightedSample(slices.Values(input), 2, weight, newRand()))
+	if again := slices.Collect(WeightedSample(slices.Values(input), 2, weight, newRand())); !reflect.DeepEqual(got, again) {
+		t.Errorf("WeightedSample() = %v and %v with the same seed", got, again)
+	}
+
+	for _, k := range []int{10, math.MaxInt / 2} {
+		all := slices.Collect(WeightedSample(slices.Values(input), k, weight, newRand()))
+		slices.Sort(all)
+		if want := []string{"a", "b", "c"}; !reflect.DeepEqual(all, want) {
+			t.Errorf("WeightedSample(%d) = %v, want %v", k, all, want)
+		}
+	}
+
+	const trials = 10000
+	rng := newRand()
+	counts := map[string]int{}
+	for range trials {
+		for v := range WeightedSample(slices.Values(input), 1, weight, rng) {
+			counts[v]++
+		}
+	}
+	if c := counts["a"]; c < trials*75/100 || c > trials*85/100 {
+		t.Errorf("WeightedSample() chose \"a\" %d times, want about %d", c, trials*8/10)
+	}
+	if counts["never"] > 0 || counts["negative"] > 0 {
+		t.Errorf("WeightedSample() chose elements without a positive weight: %v", counts)
+	}
+}
@@ -0,0 +1,163 @@
+package itertools
+
+import (
+	"context"
+	"iter"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Clock abstracts the passage of time, so that waiting can be faked in tests.
+type Clock interface {
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// RetryPolicy describes how failed operations are retried with exponential backoff.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first failure.
+	MaxRetries int
+	// InitialDelay is the delay before the first retry.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between retries. Zero means no cap.
+	MaxDelay time.Duration
+	// Multiplier is the factor the delay grows by after each retry.
+	// Values less than 1 default to 2.
+	Multiplier float64
+	// Jitter randomizes every delay by up to the given fraction of it,
+	// in both directions, so that clients failing together don't retry in lockstep.
+	// For example, 0.2 spreads a 10s delay over [8s, 12s].
+	Jitter float64
+	// Retryable reports whether an error is transient and worth retrying.
+	// If nil, every error is retried.
+	Retryable func(error) bool
+	// Clock is used to wait between retries. If nil, the system clock is used.
+	Clock Clock
+}
+
+// maxDuration is the longest representable delay.
+const maxDuration = time.Duration(math.MaxInt64)
+
+// delay returns the delay before the retry number `retry`, starting from 0.
+// Without MaxDelay, the delay is capped at maxDuration, so that it never overflows.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	limit := maxDuration
+	if p.MaxDelay > 0 {
+		limit = p.MaxDelay
+	}
+	d := float64(p.InitialDelay)
+	for range retry {
+		d *= multiplier
+		if d >= float64(limit) {
+			d = float64(limit)
+			break
+		}
+	}
+	if p.Jitter > 0 {
+		d *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	// float64(maxDuration) rounds up to 2^63, which doesn't fit in a Duration.
+	if d >= float64(maxDuration) {
+		return maxDuration
+	}
+	return time.Duration(d)
+}
+
+// shouldRetry reports whether `err` may be retried after `retries` retries.
+func (p RetryPolicy) shouldRetry(err error, retries int) bool {
+	if retries >= p.MaxRetries {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// wait blocks for `d` or until `ctx` is done, whichever happens first.
+func (p RetryPolicy) wait(ctx context.Context, d time.Duration) error {
+	clock := p.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	select {
+	case <-clock.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// do calls `op` until it succeeds, the policy gives up or `ctx` is done.
+// It returns the last error of `op`.
+func (p RetryPolicy) do(ctx context.Context, op func() error) error {
+	for retries := 0; ; retries++ {
+		err := op()
+		if err == nil || !p.shouldRetry(err, retries) {
+			return err
+		}
+		if p.wait(ctx, p.delay(retries)) != nil {
+			return err
+		}
+	}
+}
+
+// Retry returns a sequence that transparently recovers from the errors of a restartable source.
+//
+// The source is created by `factory`, which receives the number of values
+// delivered so far and must return a sequence resuming right after them.
+// When the source yields an error, it is discarded and, after waiting according
+// to `policy`, re-created by `factory`. The number of retries is counted
+// from the last value delivered, so a source making progress is never given up on.
+// Once the policy gives up, the last error is yielded and the iteration stops.
+//
+// Example:
+//
+//	events := Retry(func(resumeAfter int) iter.Seq2[Event, error] {
+//		return subscribe(topic, resumeAfter)
+//	}, RetryPolicy{MaxRetries: 5, InitialDelay: time.Second, Jitter: 0.2})
+//	for e, err := range events {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(e)
+//	}
+func Retry[V any](factory func(resumeAfter int) iter.Seq2[V, error], policy RetryPolicy) iter.Seq2[V, error] {
+	return checked2(func(yield func(V, error) bool) {
+		var zero V
+		delivered, retries := 0, 0
+		for {
+			var failure error
+			for v, err := range factory(delivered) {
+				if err != nil {
+					failure = err
+					break
+				}
+				if !yield(v, nil) {
+					return
+				}
+				delivered++
+				retries = 0
+			}
+			if failure == nil {
+				return
+			}
+			if !policy.shouldRetry(failure, retries) {
+				yield(zero, failure)
+				return
+			}
+			_ = policy.wait(context.Background(), policy.delay(retries))
+			retries++
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package itertools
+
+import (
+	"errors"
+	"iter"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeClock records the requested delays and returns immediately.
+type fakeClock struct {
+	delays []time.Duration
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+// flakySource returns a factory of sequences counting up to `n`,
+// which fail once for every occurrence of a position in `failAfter`.
+func flakySource(n int, failAfter ...int) (func(int) iter.Seq2[int, error], *[]int) {
+	failing := make(map[int]int)
+	for _, pos := range failAfter {
+		failing[pos]++
+	}
+	var resumes []int
+	factory := func(resumeAfter int) iter.Seq2[int, error] {
+		resumes = append(resumes, resumeAfter)
+		return func(yield func(int, error) bool) {
+			for i := resumeAfter; i < n; i++ {
+				if failing[i] > 0 {
+					failing[i]--
+					yield(0, errTransient)
+					return
+				}
+				if !yield(i, nil) {
+					return
+				}
+			}
+		}
+	}
+	return factory, &resumes
+}
+
+func TestRetry(t *testing.T) {
+	type testCase struct {
+		name        string
+		failAfter   []int
+		maxRetries  int
+		want        []int
+		wantErr     error
+		wantResumes []int
+		wantDelays  []time.Duration
+	}
+	tests := []testCase{
+		{
+			name:        "no failures",
+			maxRetries:  3,
+			want:        []int{0, 1, 2, 3, 4},
+			wantResumes: []int{0},
+			wantDelays:  nil,
+		},
+		{
+			name:        "resumes after failures",
+			failAfter:   []int{2, 4},
+			maxRetries:  1,
+			want:        []int{0, 1, 2, 3, 4},
+			wantResumes: []int{0, 2, 4},
+			wantDelays:  []time.Duration{time.Second, time.Second},
+		},
+		{
+			name:        "backs off on consecutive failures",
+			failAfter:   []int{0, 0, 0},
+			maxRetries:  3,
+			want:        []int{0, 1, 2, 3, 4},
+			wantResumes: []int{0, 0, 0, 0},
+			wantDelays:  []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:        "gives up",
+			failAfter:   []int{3},
+			maxRetries:  0,
+			want:        []int{0, 1, 2},
+			wantErr:     errTransient,
+			wantResumes: []int{0},
+			wantDelays:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory, resumes := flakySource(5, tt.failAfter...)
+			clock := &fakeClock{}
+			policy := RetryPolicy{MaxRetries: tt.maxRetries, InitialDelay: time.Second, Clock: clock}
+
+			var got []int
+			var gotErr error
+			for v, err := range Retry(factory, policy) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				got = append(got, v)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Retry() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("Retry() error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if !reflect.DeepEqual(*resumes, tt.wantResumes) {
+				t.Errorf("Retry() resumed after %v, want %v", *resumes, tt.wantResumes)
+			}
+			if !reflect.DeepEqual(clock.delays, tt.wantDelays) {
+				t.Errorf("Retry() waited %v, want %v", clock.delays, tt.wantDelays)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{InitialDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	want := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+	}
+	for retry, w := range want {
+		if got := p.delay(retry); got != w {
+			t.Errorf("delay(%d) = %v, want %v", retry, got, w)
+		}
+	}
+}
+
+func TestRetryPolicyDelayOverflow(t *testing.T) {
+	for _, p := range []RetryPolicy{
+		{InitialDelay: time.Second},
+		{InitialDelay: time.Second, Multiplier: 10, Jitter: 0.5},
+	} {
+		prev := time.Duration(0)
+		for retry := range 200 {
+			d := p.delay(retry)
+			if d <= 0 || p.Jitter == 0 && d < prev {
+				t.Fatalf("delay(%d) = %v after %v, want a positive non-decreasing delay", retry, d, prev)
+			}
+			prev = d
+		}
+		if p.Jitter == 0 && prev != maxDuration {
+			t.Errorf("delay(199) = %v, want %v", prev, maxDuration)
+		}
+	}
+}
+
+func TestRetryPolicyJitter(t *testing.T) {
+	p := RetryPolicy{InitialDelay: 10 * time.Second, Jitter: 0.2}
+	for range 100 {
+		if d := p.delay(0); d < 8*time.Second || d > 12*time.Second {
+			t.Fatalf("delay(0) = %v, want within [8s, 12s]", d)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package itertools
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrUnsorted is reported when a sequence expected to be sorted isn't.
+var ErrUnsorted = errors.New("itertools: sequence is not sorted")
+
+// IsSorted reports whether the elements of `iter` are sorted in ascending order.
+// It stops at the first element out of order, so it can be used on infinite
+// sequences known to be unsorted.
+//
+// Example:
+//
+//	fmt.Println(IsSorted(FromElements(1, 2, 2, 5))) // true
+//	fmt.Println(IsSorted(FromElements(1, 3, 2)))    // false
+func IsSorted[V cmp.Ordered](iter iter.Seq[V]) bool {
+	return IsSortedFunc(iter, cmp.Compare[V])
+}
+
+// IsSortedFunc is like IsSorted, but uses `cmp` to compare elements.
+func IsSortedFunc[V any](iter iter.Seq[V], cmp func(V, V) int) bool {
+	for _, err := range CheckSorted(iter, cmp) {
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckSorted returns a sequence of the elements of `iter` paired with nil errors,
+// as long as they are sorted according to `cmp`. At the first element
+// less than the previous one, it yields the zero value with an error
+// wrapping ErrUnsorted and stops.
+//
+// Example:
+//
+//	for v, err := range CheckSorted(FromElements(1, 3, 2), cmp.Compare[int]) {
+//		fmt.Println(v, err) // 1 <nil>, 3 <nil>, 0 itertools: sequence is not sorted: element 2 at index 2 ...
+//	}
+func CheckSorted[V any](iter iter.Seq[V], cmp func(V, V) int) iter.Seq2[V, error] {
+	return checked2(func(yield func(V, error) bool) {
+		var prev V
+		i := 0
+		for v := range iter {
+			if i > 0 && cmp(prev, v) > 0 {
+				var zero V
+				yield(zero, fmt.Errorf("%w: element %v at index %d is less than the previous one %v", ErrUnsorted, v, i, prev))
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+			prev = v
+			i++
+		}
+	})
+}
+
+// AssertSorted returns a sequence of the elements of `iter` that panics
+// with an error wrapping ErrUnsorted as soon as an element is less than the previous one
+// according to `cmp`. It guards the inputs of the operators expecting sorted sequences.
+//
+// Example:
+//
+//	a := AssertSorted(FromElements(1, 3, 2), cmp.Compare[int])
+//	for v := range Union(cmp.Compare[int], a, FromElements(2)) {
+//		fmt.Println(v) // 1, 2, then panics
+//	}
+func AssertSorted[V any](iter iter.Seq[V], cmp func(V, V) int) iter.Seq[V] {
+	return checked(func(yield func(V) bool) {
+		for v, err := range CheckSorted(iter, cmp) {
+			if err != nil {
+				panic(err)
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// tagged is an element along with the index of the sequence it comes from.
+type tagged[V any] struct {
+	v   V
+	src int
+}
+
+// setOp merges the sorted `iters` and, for every group of equal elements, yields
+// as many of them as `copies` returns given the number of occurrences in each sequence.
+// Without `bag`, the occurrences are capped at one, treating the sequences as sets.
+func setOp[V any](cmp func(V, V) int, iters []iter.Seq[V], bag bool, copies func(counts []int) int) iter.Seq[V] {
+	seqs := make([]iter.Seq[tagged[V]], len(iters))
+	for i, seq := range iters {
+		seqs[i] = func(yield func(tagged[V]) bool) {
+			for v := range seq {
+				if !yield(tagged[V]{v, i}) {
+					return
+				}
+			}
+		}
+	}
+	merged := mergeSorted(func(a, b tagged[V]) int { return cmp(a.v, b.v) }, true, seqs)
+
+	return checked(func(yield func(V) bool) {
+		var group []V
+		counts := make([]int, len(iters))
+
+		// flush yields the elements of the current group and starts a new one.
+		flush := func() bool {
+			if !bag {
+				for i, c := range counts {
+					counts[i] = min(c, 1)
+				}
+			}
+			n := min(copies(counts), len(group))
+			for _, v := range group[:n] {
+				if !yield(v) {
+					return false
+				}
+			}
+			group = group[:0]
+			clear(counts)
+			return true
+		}
+
+		for t := range merged {
+			if len(group) > 0 && cmp(group[0], t.v) != 0 && !flush() {
+				return
+			}
+			group = append(group, t.v)
+			counts[t.src]++
+		}
+		if len(group) > 0 {
+			flush()
+		}
+	})
+}
+
+// Union returns a sequence of the elements present in any of `iters`,
+// given that each of them is sorted according to `cmp`. The result is sorted too,
+// and every element appears in it once, even if it is repeated in the inputs.
+// Elements considered equal by `cmp` are interchangeable: the first one is yielded.
+//
+// Like the other set operations, it is lazy and keeps only the elements
+// equal to the current one in memory. Inputs that aren't sorted produce
+// unspecified results; wrap them with AssertSorted to detect that.
+//
+// Example:
+//
+//	for v := range Union(cmp.Compare[int], FromElements(1, 3, 5), FromElements(1, 2, 3)) {
+//		fmt.Println(v) // 1, 2, 3, 5
+//	}
+func Union[V any](cmp func(V, V) int, iters ...iter.Seq[V]) iter.Seq[V] {
+	return setOp(cmp, iters, false, unionCopies)
+}
+
+// UnionBag is like Union, but treats the inputs as multisets:
+// an element appears as many times as in the input where it is repeated the most.
+//
+// Example:
+//
+//	for v := range UnionBag(cmp.Compare[int], FromElements(1, 1, 2), FromElements(1, 2, 2)) {
+//		fmt.Println(v) // 1, 1, 2, 2
+//	}
+func UnionBag[V any](cmp func(V, V) int, iters ...iter.Seq[V]) iter.Seq[V] {
+	return setOp(cmp, iters, true, unionCopies)
+}
+
+func unionCopies(counts []int) int {
+	n := 0
+	for _, c := range counts {
+		n = max(n, c)
+	}
+	return n
+}
+
+// Intersect returns a sequence of the elements present in every one of `iters`,
+// given that each of them is sorted according to `cmp`.
+// Every element appears in the result once. See Union for details.
+//
+// Example:
+//
+//	for v := range Intersect(cmp.Compare[int], FromElements(1, 2, 3, 5), FromElements(2, 3, 4)) {
+//		fmt.Println(v) // 2, 3
+//	}
+func Intersect[V any](cmp func(V, V) int, iters ...iter.Seq[V]) iter.Seq[V] {
+	return setOp(cmp, iters, false, intersectCopies)
+}
+
+// IntersectBag is like Intersect, but treats the inputs as multisets:
+// an element appears as many times as in the input where it is repeated the least.
+//
+// Example:
+//
+//	for v := range IntersectBag(cmp.Compare[int], FromElements(1, 1, 1, 2), FromElements(1, 1, 3)) {
+//		fmt.Println(v) // 1, 1
+//	}
+func IntersectBag[V any](cmp func(V, V) int, iters ...iter.Seq[V]) iter.Seq[V] {
+	return setOp(cmp, iters, true, intersectCopies)
+}
+
+func intersectCopies(counts []int) int {
+	if len(counts) == 0 {
+		return 0
+	}
+	n := counts[0]
+	for _, c := range counts[1:] {
+		n = min(n, c)
+	}
+	return n
+}
+
+// Difference returns a sequence of the elements of `iter` that aren't present in any of `others`,
+// given that all of them are sorted according to `cmp`.
+// Every element appears in the result once. See Union for details.
+//
+// Example:
+//
+//	for v := range Difference(cmp.Compare[int], FromElements(1, 2, 3, 4), FromElements(2), FromElements(4)) {
+//		fmt.Println(v) // 1, 3
+//	}
+func Difference[V any](cmp func(V, V) int, iter iter.Seq[V], others ...iter.Seq[V]) iter.Seq[V] {
+	return setOp(cmp, append(append(others[:0:0], iter), others...), false, differenceCopies)
+}
+
+// DifferenceBag is like Difference, but treats the inputs as multisets:
+// every occurrence of an element in `others` removes one occurrence of it from `iter`.
+//
+// Example:
+//
+//	for v := range DifferenceBag(cmp.Compare[int], FromElements(1, 1, 1, 2), FromElements(1)) {
+//		fmt.Println(v) // 1, 1, 2
+//	}
+func DifferenceBag[V any](cmp func(V, V) int, iter iter.Seq[V], others ...iter.Seq[V]) iter.Seq[V] {
+	return setOp(cmp, append(append(others[:0:0], iter), others...), true, differenceCopies)
+}
+
+func differenceCopies(counts []int) int {
+	n := counts[0]
+	for _, c := range counts[1:] {
+		n -= c
+	}
+	return max(n, 0)
+}
+
+// SymmetricDifference returns a sequence of the elements present in an odd number of `iters`,
+// given that each of them is sorted according to `cmp`. For two inputs, these are
+// the elements present in exactly one of them. Every element appears in the result once.
+// See Union for details.
+//
+// Example:
+//
+//	for v := range SymmetricDifference(cmp.Compare[int], FromElements(1, 2, 3), FromElements(2, 3, 4)) {
+//		fmt.Println(v) // 1, 4
+//	}
+func SymmetricDifference[V any](cmp func(V, V) int, iters ...iter.Seq[V]) iter.Seq[V] {
+	return setOp(cmp, iters, false, symmetricDifferenceCopies)
+}
+
+// SymmetricDifferenceBag is like SymmetricDifference, but treats the inputs as multisets:
+// for two inputs, an element appears as many times as the difference
+// between the numbers of its occurrences in them. More inputs are combined
+// pairwise from left to right.
+//
+// Example:
+//
+//	for v := range SymmetricDifferenceBag(cmp.Compare[int], FromElements(1, 1, 1, 2), FromElements(1, 3)) {
+//		fmt.Println(v) // 1, 1, 2, 3
+//	}
+func SymmetricDifferenceBag[V any](cmp func(V, V) int, iters ...iter.Seq[V]) iter.Seq[V] {
+	return setOp(cmp, iters, true, symmetricDifferenceCopies)
+}
+
+func symmetricDifferenceCopies(counts []int) int {
+	n := 0
+	for _, c := range counts {
+		n = max(n-c, c-n)
+	}
+	return n
+}
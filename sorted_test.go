@@ -0,0 +1,194 @@
+package itertools
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestIsSorted(t *testing.T) {
+	type testCase struct {
+		name  string
+		input []int
+		want  bool
+	}
+	tests := []testCase{
+		{
+			name:  "sorted with duplicates",
+			input: []int{1, 2, 2, 5},
+			want:  true,
+		},
+		{
+			name:  "unsorted",
+			input: []int{1, 3, 2},
+			want:  false,
+		},
+		{
+			name:  "empty",
+			input: nil,
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSorted(slices.Values(tt.input)); got != tt.want {
+				t.Errorf("IsSorted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	desc := func(a, b int) int { return cmp.Compare(b, a) }
+	if !IsSortedFunc(FromElements(3, 2, 2, 1), desc) {
+		t.Errorf("IsSortedFunc() = false, want true")
+	}
+	if IsSorted(Chain(FromElements(2, 1), Count(0, 1))) {
+		t.Errorf("IsSorted() of an unsorted infinite sequence = true, want false")
+	}
+}
+
+func TestCheckSorted(t *testing.T) {
+	var values []int
+	var err error
+	for v, e := range CheckSorted(FromElements(1, 3, 2, 4), cmp.Compare[int]) {
+		if e != nil {
+			err = e
+			break
+		}
+		values = append(values, v)
+	}
+	if want := []int{1, 3}; !reflect.DeepEqual(values, want) {
+		t.Errorf("CheckSorted() = %v, want %v", values, want)
+	}
+	if !errors.Is(err, ErrUnsorted) || !strings.Contains(err.Error(), "index 2") {
+		t.Errorf("CheckSorted() error = %v, want ErrUnsorted at index 2", err)
+	}
+}
+
+func TestAssertSorted(t *testing.T) {
+	if got, want := slices.Collect(AssertSorted(FromElements(1, 1, 2), cmp.Compare[int])), []int{1, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AssertSorted() = %v, want %v", got, want)
+	}
+
+	var got []int
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrUnsorted) {
+			t.Errorf("AssertSorted() panicked with %v, want ErrUnsorted", err)
+		}
+		if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+			t.Errorf("AssertSorted() = %v before panicking, want %v", got, want)
+		}
+	}()
+	a := AssertSorted(FromElements(1, 3, 2), cmp.Compare[int])
+	for v := range Union(cmp.Compare[int], a, FromElements(2)) {
+		got = append(got, v)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	type testCase struct {
+		name  string
+		op    func(func(int, int) int, ...iter.Seq[int]) iter.Seq[int]
+		iters [][]int
+		want  []int
+	}
+	difference := func(cmp func(int, int) int, iters ...iter.Seq[int]) iter.Seq[int] {
+		return Difference(cmp, iters[0], iters[1:]...)
+	}
+	differenceBag := func(cmp func(int, int) int, iters ...iter.Seq[int]) iter.Seq[int] {
+		return DifferenceBag(cmp, iters[0], iters[1:]...)
+	}
+	tests := []testCase{
+		{
+			name:  "Union",
+			op:    Union[int],
+			iters: [][]int{{1, 3, 5}, {1, 2, 3}},
+			want:  []int{1, 2, 3, 5},
+		},
+		{
+			name:  "Union of repeated elements",
+			op:    Union[int],
+			iters: [][]int{{1, 1, 2}, {1, 2, 2}, {}},
+			want:  []int{1, 2},
+		},
+		{
+			name:  "UnionBag",
+			op:    UnionBag[int],
+			iters: [][]int{{1, 1, 2}, {1, 2, 2}},
+			want:  []int{1, 1, 2, 2},
+		},
+		{
+			name:  "Intersect",
+			op:    Intersect[int],
+			iters: [][]int{{1, 2, 3, 5}, {2, 3, 4}, {0, 3}},
+			want:  []int{3},
+		},
+		{
+			name:  "Intersect with an empty input",
+			op:    Intersect[int],
+			iters: [][]int{{1, 2}, {}},
+			want:  nil,
+		},
+		{
+			name:  "IntersectBag",
+			op:    IntersectBag[int],
+			iters: [][]int{{1, 1, 1, 2}, {1, 1, 3}},
+			want:  []int{1, 1},
+		},
+		{
+			name:  "Difference",
+			op:    difference,
+			iters: [][]int{{1, 2, 2, 3, 4}, {2}, {4}},
+			want:  []int{1, 3},
+		},
+		{
+			name:  "DifferenceBag",
+			op:    differenceBag,
+			iters: [][]int{{1, 1, 1, 2}, {1}},
+			want:  []int{1, 1, 2},
+		},
+		{
+			name:  "SymmetricDifference",
+			op:    SymmetricDifference[int],
+			iters: [][]int{{1, 2, 3}, {2, 3, 4}},
+			want:  []int{1, 4},
+		},
+		{
+			name:  "SymmetricDifference of three",
+			op:    SymmetricDifference[int],
+			iters: [][]int{{1, 2, 3}, {2, 3}, {3}},
+			want:  []int{1, 3},
+		},
+		{
+			name:  "SymmetricDifferenceBag",
+			op:    SymmetricDifferenceBag[int],
+			iters: [][]int{{1, 1, 1, 2}, {1, 3}},
+			want:  []int{1, 1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iters := make([]iter.Seq[int], len(tt.iters))
+			for i, s := range tt.iters {
+				iters[i] = slices.Values(s)
+			}
+			if got := slices.Collect(tt.op(cmp.Compare[int], iters...)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetOperationsAreLazy(t *testing.T) {
+	evens, threes := Count(0, 2), Count(0, 3)
+	if got, want := slices.Collect(Take(Intersect(cmp.Compare[int], evens, threes), 3)), []int{0, 6, 12}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(Take(Union(cmp.Compare[int], evens, threes), 5)), []int{0, 2, 3, 4, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Union() = %v, want %v", got, want)
+	}
+}
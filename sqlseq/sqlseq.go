@@ -0,0 +1,189 @@
+// Package sqlseq provides lazy sequences over the results of database/sql queries.
+package sqlseq
+
+import (
+	"database/sql"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+)
+
+// Rows returns a sequence of values scanned from `rows` with `scan`.
+//
+// The rows are closed when the iteration ends, whether the rows are exhausted,
+// an error occurs or the consumer stops early. Errors returned by `scan`,
+// rows.Err and rows.Close are yielded, after which the iteration stops.
+// Since the rows can only be read once, the sequence is single-use.
+//
+// Example:
+//
+//	rows, err := db.QueryContext(ctx, "SELECT name FROM users")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	names := Rows(rows, func(rows *sql.Rows) (string, error) {
+//		var name string
+//		err := rows.Scan(&name)
+//		return name, err
+//	})
+//	for name, err := range names {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(name)
+//	}
+func Rows[T any](rows *sql.Rows, scan func(*sql.Rows) (T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		closed := false
+		defer func() {
+			if !closed {
+				_ = rows.Close()
+			}
+		}()
+
+		for rows.Next() {
+			v, err := scan(rows)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+		closed = true
+		if err := rows.Close(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// Structs returns a sequence of structs of type T scanned from `rows`.
+// It is like Rows(rows, ScanStruct[T]), but matches the columns
+// to the fields once for the whole result set instead of once per row.
+//
+// It panics if T is not a struct type.
+func Structs[T any](rows *sql.Rows) iter.Seq2[T, error] {
+	typ := structType[T]("Structs")
+	var s *structScanner
+	return Rows(rows, func(rows *sql.Rows) (T, error) {
+		var v T
+		if s == nil {
+			var err error
+			if s, err = newStructScanner(typ, rows); err != nil {
+				return v, err
+			}
+		}
+		return v, s.scan(rows, reflect.ValueOf(&v).Elem())
+	})
+}
+
+// ScanStruct scans the current row into a struct of type T,
+// matching the result columns by name.
+//
+// Column names are matched against the `db` tags of the fields of T,
+// falling back to a case-insensitive match of the field names.
+// Fields tagged with `db:"-"` are ignored. It is an error for a column
+// to have no matching field. Fields promoted from embedded struct pointers
+// are matched too, allocating the embedded structs.
+//
+// It panics if T is not a struct type.
+func ScanStruct[T any](rows *sql.Rows) (T, error) {
+	var v T
+	s, err := newStructScanner(structType[T]("ScanStruct"), rows)
+	if err != nil {
+		return v, err
+	}
+	return v, s.scan(rows, reflect.ValueOf(&v).Elem())
+}
+
+// structType returns the type T, panicking on behalf of `fn` if it is not a struct type.
+func structType[T any](fn string) reflect.Type {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		panic("sqlseq: " + fn + " requires a struct type, got " + typ.String())
+	}
+	return typ
+}
+
+// structScanner scans rows into structs, with the columns of the rows already matched to the fields.
+type structScanner struct {
+	columns []string
+	indexes [][]int // the index of the field matching each column
+}
+
+func newStructScanner(typ reflect.Type, rows *sql.Rows) (*structScanner, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := fieldsByName(typ)
+	indexes := make([][]int, len(columns))
+	for i, col := range columns {
+		idx, ok := fields[col]
+		if !ok {
+			idx, ok = fields[strings.ToLower(col)]
+		}
+		if !ok {
+			return nil, fmt.Errorf("sqlseq: no field of %s matches column %q", typ, col)
+		}
+		indexes[i] = idx
+	}
+	return &structScanner{columns: columns, indexes: indexes}, nil
+}
+
+// scan scans the current row into the struct `v`, which must be addressable.
+func (s *structScanner) scan(rows *sql.Rows, v reflect.Value) error {
+	dest := make([]any, len(s.indexes))
+	for i, idx := range s.indexes {
+		field, err := fieldByIndex(v, idx)
+		if err != nil {
+			return fmt.Errorf("sqlseq: column %q: %w", s.columns[i], err)
+		}
+		dest[i] = field.Addr().Interface()
+	}
+	return rows.Scan(dest...)
+}
+
+// fieldsByName indexes the fields of `typ` by their tag, or their lowercased name.
+func fieldsByName(typ reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		switch tag := f.Tag.Get("db"); tag {
+		case "-":
+		case "":
+			fields[strings.ToLower(f.Name)] = f.Index
+		default:
+			fields[tag] = f.Index
+		}
+	}
+	return fields
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but allocates the nil embedded struct pointers
+// on the path to the field. It fails if such a pointer can't be set because its type is unexported.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
@@ -0,0 +1,339 @@
+package sqlseq
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"iter"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeDriver serves fixed result sets, keyed by the query text.
+type fakeDriver struct{}
+
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error // returned after all rows have been read
+}
+
+var (
+	fakeResults = map[string]fakeResult{
+		"users": {
+			columns: []string{"id", "name", "Email"},
+			rows: [][]driver.Value{
+				{int64(1), "Bob", "bob@example.com"},
+				{int64(2), "Alice", "alice@example.com"},
+				{int64(3), "Eve", "eve@example.com"},
+			},
+		},
+		"broken": {
+			columns: []string{"id"},
+			rows:    [][]driver.Value{{int64(1)}},
+			err:     errors.New("connection reset"),
+		},
+		"columns": {
+			columns: []string{"id", "name"},
+			rows: [][]driver.Value{
+				{int64(1), "Bob"},
+				{int64(2), "Alice"},
+			},
+		},
+		"unknown column": {
+			columns: []string{"id", "missing"},
+			rows:    [][]driver.Value{{int64(1), "x"}},
+		},
+	}
+	openRows atomic.Int64
+	// columnsCalls counts how many times the columns of a result set were requested.
+	columnsCalls atomic.Int64
+)
+
+func init() {
+	sql.Register("sqlseqfake", fakeDriver{})
+}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{query}, nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeStmt struct {
+	query string
+}
+
+func (fakeStmt) Close() error {
+	return nil
+}
+
+func (fakeStmt) NumInput() int {
+	return 0
+}
+
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	res, ok := fakeResults[s.query]
+	if !ok {
+		return nil, errors.New("unknown query " + s.query)
+	}
+	openRows.Add(1)
+	return &fakeRows{result: res}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	pos    int
+	closed bool
+}
+
+func (r *fakeRows) Columns() []string {
+	columnsCalls.Add(1)
+	return r.result.columns
+}
+
+func (r *fakeRows) Close() error {
+	if !r.closed {
+		r.closed = true
+		openRows.Add(-1)
+	}
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.result.rows) {
+		if r.result.err != nil {
+			return r.result.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func query(t *testing.T, q string) *sql.Rows {
+	t.Helper()
+	db, err := sql.Open("sqlseqfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	rows, err := db.Query(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func scanName(rows *sql.Rows) (string, error) {
+	var id int
+	var name, email string
+	err := rows.Scan(&id, &name, &email)
+	return name, err
+}
+
+type user struct {
+	ID    int    `db:"id"`
+	Name  string `db:"name"`
+	Email string // matched case-insensitively
+}
+
+func TestRows(t *testing.T) {
+	type testCase struct {
+		name  string
+		limit int
+		want  []string
+	}
+	tests := []testCase{
+		{
+			name:  "all rows",
+			limit: -1,
+			want:  []string{"Bob", "Alice", "Eve"},
+		},
+		{
+			name:  "early break",
+			limit: 2,
+			want:  []string{"Bob", "Alice"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for name, err := range Rows(query(t, "users"), scanName) {
+				if err != nil {
+					t.Fatalf("Rows() error = %v", err)
+				}
+				if len(got) == tt.limit {
+					break
+				}
+				got = append(got, name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rows() = %v, want %v", got, tt.want)
+			}
+			if n := openRows.Load(); n != 0 {
+				t.Errorf("Rows() left %d rows open", n)
+			}
+		})
+	}
+}
+
+func TestRowsError(t *testing.T) {
+	type testCase struct {
+		name    string
+		query   string
+		scan    func(*sql.Rows) (int, error)
+		wantErr string
+	}
+	tests := []testCase{
+		{
+			name:  "rows error",
+			query: "broken",
+			scan: func(rows *sql.Rows) (int, error) {
+				var id int
+				err := rows.Scan(&id)
+				return id, err
+			},
+			wantErr: "connection reset",
+		},
+		{
+			name:  "scan error",
+			query: "users",
+			scan: func(rows *sql.Rows) (int, error) {
+				return 0, errors.New("bad row")
+			},
+			wantErr: "bad row",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotErr error
+			for _, err := range Rows(query(t, tt.query), tt.scan) {
+				if err != nil {
+					gotErr = err
+				}
+			}
+			if gotErr == nil || gotErr.Error() != tt.wantErr {
+				t.Errorf("Rows() error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if n := openRows.Load(); n != 0 {
+				t.Errorf("Rows() left %d rows open", n)
+			}
+		})
+	}
+}
+
+func TestStructs(t *testing.T) {
+	var got []user
+	for u, err := range Structs[user](query(t, "users")) {
+		if err != nil {
+			t.Fatalf("Structs() error = %v", err)
+		}
+		got = append(got, u)
+	}
+	want := []user{
+		{1, "Bob", "bob@example.com"},
+		{2, "Alice", "alice@example.com"},
+		{3, "Eve", "eve@example.com"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Structs() = %v, want %v", got, want)
+	}
+}
+
+func TestStructsUnknownColumn(t *testing.T) {
+	var gotErr error
+	for _, err := range Structs[user](query(t, "unknown column")) {
+		gotErr = err
+	}
+	if gotErr == nil {
+		t.Errorf("Structs() expected an error for an unknown column")
+	}
+	if n := openRows.Load(); n != 0 {
+		t.Errorf("Structs() left %d rows open", n)
+	}
+}
+
+func TestStructsMatchesColumnsOnce(t *testing.T) {
+	// database/sql requests the columns itself, so compare with a scan that doesn't.
+	columns := func(seq iter.Seq2[user, error]) int64 {
+		before := columnsCalls.Load()
+		for _, err := range seq {
+			if err != nil {
+				t.Fatalf("Structs() error = %v", err)
+			}
+		}
+		return columnsCalls.Load() - before
+	}
+	scanUser := func(rows *sql.Rows) (user, error) {
+		var u user
+		err := rows.Scan(&u.ID, &u.Name, &u.Email)
+		return u, err
+	}
+	base := columns(Rows(query(t, "users"), scanUser))
+	if calls := columns(Structs[user](query(t, "users"))) - base; calls != 1 {
+		t.Errorf("Structs() requested the columns %d times for 3 rows, want 1", calls)
+	}
+}
+
+type Named struct {
+	Name string `db:"name"`
+}
+
+type named struct {
+	Name string `db:"name"`
+}
+
+func TestStructsEmbeddedPointer(t *testing.T) {
+	type record struct {
+		*Named
+		ID int `db:"id"`
+	}
+	var got []record
+	for r, err := range Structs[record](query(t, "columns")) {
+		if err != nil {
+			t.Fatalf("Structs() error = %v", err)
+		}
+		got = append(got, r)
+	}
+	want := []record{{&Named{"Bob"}, 1}, {&Named{"Alice"}, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Structs() = %v, want %v", got, want)
+	}
+
+	type unexported struct {
+		*named
+		ID int `db:"id"`
+	}
+	var gotErr error
+	for _, err := range Structs[unexported](query(t, "columns")) {
+		gotErr = err
+	}
+	if gotErr == nil || !strings.Contains(gotErr.Error(), `column "name"`) {
+		t.Errorf("Structs() error = %v, want an error for column \"name\"", gotErr)
+	}
+	if n := openRows.Load(); n != 0 {
+		t.Errorf("Structs() left %d rows open", n)
+	}
+}
@@ -0,0 +1,192 @@
+package stats
+
+import (
+	"iter"
+	"math"
+	"slices"
+
+	it "github.com/dzherb/go-itertools"
+)
+
+// DefaultCompression is the compression of a Digest created by ApproxQuantile.
+const DefaultCompression = 100
+
+// centroid is a cluster of nearby values summarized by their mean and count.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// Digest estimates quantiles of a stream of values in bounded memory
+// using the merging t-digest algorithm. It keeps the values near
+// the extreme quantiles more precisely than those near the median,
+// so tail latencies such as p99 are estimated with a small relative error.
+//
+// The memory it uses grows with its compression, not with the number of values:
+// a higher compression gives more precise estimates at the cost of memory.
+//
+// A Digest must be created with NewDigest. It isn't safe for concurrent use.
+//
+// Example:
+//
+//	d := stats.NewDigest(100)
+//	for latency := range latencies {
+//		d.Add(latency)
+//	}
+//	fmt.Println(d.Quantile(0.99))
+type Digest struct {
+	compression float64
+	centroids   []centroid
+	buf         []centroid
+	count       float64
+	min, max    float64
+}
+
+const compressionPanicMessage = "stats: compression must be positive"
+
+// NewDigest creates an empty Digest with the given `compression`.
+// Values between 100 and 1000 suit most uses.
+//
+// It panics if `compression` isn't positive.
+func NewDigest(compression float64) *Digest {
+	if !(compression > 0) {
+		panic(compressionPanicMessage)
+	}
+	return &Digest{
+		compression: compression,
+		buf:         make([]centroid, 0, int(math.Ceil(compression))*5),
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Add adds `x` to the digest. NaN values are ignored.
+func (d *Digest) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	d.buf = append(d.buf, centroid{x, 1})
+	d.count++
+	d.min, d.max = min(d.min, x), max(d.max, x)
+	if len(d.buf) == cap(d.buf) {
+		d.compress()
+	}
+}
+
+// Count returns the number of values added to the digest.
+func (d *Digest) Count() int {
+	return int(d.count)
+}
+
+// scale maps quantile `q` to the index of its centroid, limiting
+// the size of centroids near the extremes more than near the median.
+func (d *Digest) scale(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// inverseScale is the inverse of scale.
+func (d *Digest) inverseScale(k float64) float64 {
+	if k >= d.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/d.compression) + 1) / 2
+}
+
+// compress merges the buffered values into the centroids.
+func (d *Digest) compress() {
+	if len(d.buf) == 0 {
+		return
+	}
+	all := append(d.buf, d.centroids...)
+	slices.SortFunc(all, func(a, b centroid) int {
+		switch {
+		case a.mean < b.mean:
+			return -1
+		case a.mean > b.mean:
+			return 1
+		}
+		return 0
+	})
+
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	cur := all[0]
+	before := 0.0
+	limit := d.count * d.inverseScale(d.scale(0)+1)
+	for _, c := range all[1:] {
+		if before+cur.weight+c.weight <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		merged = append(merged, cur)
+		before += cur.weight
+		limit = d.count * d.inverseScale(d.scale(before/d.count)+1)
+		cur = c
+	}
+	d.centroids = append(merged, cur)
+	d.buf = d.buf[:0]
+}
+
+// Quantile returns an estimate of the `q`-quantile of the values added so far,
+// or NaN if the digest is empty.
+//
+// It panics if `q` isn't in [0, 1].
+func (d *Digest) Quantile(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic(quantilePanicMessage)
+	}
+	d.compress()
+	if d.count == 0 {
+		return math.NaN()
+	}
+	switch {
+	case q == 0:
+		return d.min
+	case q == 1:
+		return d.max
+	case len(d.centroids) == 1:
+		return d.centroids[0].mean
+	}
+
+	// Each centroid is considered to sit at the middle of the ranks it covers,
+	// and the quantile is interpolated between the two closest centers.
+	target := q * d.count
+	first, last := d.centroids[0], d.centroids[len(d.centroids)-1]
+	if target < first.weight/2 {
+		return d.min + (first.mean-d.min)*target/(first.weight/2)
+	}
+	if target > d.count-last.weight/2 {
+		return last.mean + (d.max-last.mean)*(target-(d.count-last.weight/2))/(last.weight/2)
+	}
+
+	center := first.weight / 2
+	for i := 1; i < len(d.centroids); i++ {
+		prev, c := d.centroids[i-1], d.centroids[i]
+		next := center + (prev.weight+c.weight)/2
+		if target <= next {
+			return prev.mean + (c.mean-prev.mean)*(target-center)/(next-center)
+		}
+		center = next
+	}
+	return last.mean
+}
+
+// ApproxQuantile returns an estimate of the `q`-quantile of the elements of `seq`
+// computed in bounded memory with a Digest of DefaultCompression.
+// It returns false if the sequence is empty.
+//
+// It panics if `q` isn't in [0, 1].
+//
+// Example:
+//
+//	p99, ok := stats.ApproxQuantile(latencies, 0.99)
+func ApproxQuantile[T it.Number](seq iter.Seq[T], q float64) (float64, bool) {
+	if !(q >= 0 && q <= 1) {
+		panic(quantilePanicMessage)
+	}
+	d := NewDigest(DefaultCompression)
+	for v := range seq {
+		d.Add(float64(v))
+	}
+	return d.Quantile(q), d.count > 0
+}
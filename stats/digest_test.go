@@ -0,0 +1,82 @@
+package stats
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	it "github.com/dzherb/go-itertools"
+)
+
+func TestDigest(t *testing.T) {
+	const n = 100_000
+	rng := rand.New(rand.NewPCG(1, 2))
+	values := make([]float64, n)
+	d := NewDigest(100)
+	for i := range values {
+		values[i] = rng.NormFloat64()
+		d.Add(values[i])
+	}
+	slices.Sort(values)
+
+	if got := d.Count(); got != n {
+		t.Errorf("Digest.Count() = %v, want %v", got, n)
+	}
+	for _, q := range []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999} {
+		got := d.Quantile(q)
+		// Check the error in terms of rank, which is what t-digest bounds.
+		rank := float64(sortedIndex(values, got)) / n
+		if tolerance := 0.005 + 2*q*(1-q)*0.01; math.Abs(rank-q) > tolerance {
+			t.Errorf("Digest.Quantile(%v) = %v with rank %v, want within %v", q, got, rank, tolerance)
+		}
+	}
+	if got := d.Quantile(0); got != values[0] {
+		t.Errorf("Digest.Quantile(0) = %v, want %v", got, values[0])
+	}
+	if got := d.Quantile(1); got != values[n-1] {
+		t.Errorf("Digest.Quantile(1) = %v, want %v", got, values[n-1])
+	}
+	if len(d.centroids) > 200 {
+		t.Errorf("Digest kept %d centroids, want a bounded number", len(d.centroids))
+	}
+}
+
+func sortedIndex(s []float64, x float64) int {
+	i, _ := slices.BinarySearch(s, x)
+	return i
+}
+
+func TestDigestSmall(t *testing.T) {
+	d := NewDigest(100)
+	if got := d.Quantile(0.5); !math.IsNaN(got) {
+		t.Errorf("Digest.Quantile() of an empty digest = %v, want NaN", got)
+	}
+	d.Add(42)
+	d.Add(math.NaN())
+	if got := d.Quantile(0.5); got != 42 {
+		t.Errorf("Digest.Quantile() = %v, want 42", got)
+	}
+	if got := d.Count(); got != 1 {
+		t.Errorf("Digest.Count() = %v, want 1", got)
+	}
+}
+
+func TestApproxQuantile(t *testing.T) {
+	got, ok := ApproxQuantile(it.Range(0, 10_001, 1), 0.5)
+	if !ok || math.Abs(got-5000) > 50 {
+		t.Errorf("ApproxQuantile() = %v, %v, want about 5000, true", got, ok)
+	}
+	if _, ok := ApproxQuantile(it.FromElements[int](), 0.5); ok {
+		t.Errorf("ApproxQuantile() of an empty sequence = true, want false")
+	}
+}
+
+func TestNewDigestPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != compressionPanicMessage {
+			t.Errorf("NewDigest() panicked with %v, want %q", r, compressionPanicMessage)
+		}
+	}()
+	NewDigest(0)
+}
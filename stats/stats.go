@@ -0,0 +1,282 @@
+// Package stats provides statistics computed over numeric sequences.
+//
+// Aggregators consume the whole sequence in a single pass and, except for
+// the exact quantiles, keep a constant amount of memory. Every aggregator
+// reports whether the sequence had any elements, since most statistics
+// are undefined for an empty one. The Running functions return sequences
+// of intermediate results, one per element, suitable for live dashboards.
+package stats
+
+import (
+	"iter"
+	"math"
+	"slices"
+
+	it "github.com/dzherb/go-itertools"
+)
+
+// Moments accumulates the count, mean and variance of a series of values
+// using Welford's algorithm, which stays numerically stable for long series.
+// The zero value is an empty series ready to use.
+type Moments struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+// Add adds `x` to the series.
+func (m *Moments) Add(x float64) {
+	m.n++
+	delta := x - m.mean
+	m.mean += delta / float64(m.n)
+	m.m2 += delta * (x - m.mean)
+}
+
+// Count returns the number of values in the series.
+func (m Moments) Count() int {
+	return m.n
+}
+
+// Mean returns the arithmetic mean of the series, or NaN if it is empty.
+func (m Moments) Mean() float64 {
+	if m.n == 0 {
+		return math.NaN()
+	}
+	return m.mean
+}
+
+// Variance returns the population variance of the series, or NaN if it is empty.
+func (m Moments) Variance() float64 {
+	if m.n == 0 {
+		return math.NaN()
+	}
+	return m.m2 / float64(m.n)
+}
+
+// SampleVariance returns the unbiased sample variance of the series,
+// or NaN if it has fewer than two values.
+func (m Moments) SampleVariance() float64 {
+	if m.n < 2 {
+		return math.NaN()
+	}
+	return m.m2 / float64(m.n-1)
+}
+
+// StdDev returns the population standard deviation of the series, or NaN if it is empty.
+func (m Moments) StdDev() float64 {
+	return math.Sqrt(m.Variance())
+}
+
+// moments accumulates the moments of all elements of `seq`.
+func moments[T it.Number](seq iter.Seq[T]) Moments {
+	var m Moments
+	for v := range seq {
+		m.Add(float64(v))
+	}
+	return m
+}
+
+// Mean returns the arithmetic mean of the elements of `seq`.
+// It returns false if the sequence is empty.
+//
+// Example:
+//
+//	mean, ok := stats.Mean(itertools.FromElements(1, 2, 3, 4))
+//	fmt.Println(mean, ok) // 2.5 true
+func Mean[T it.Number](seq iter.Seq[T]) (float64, bool) {
+	m := moments(seq)
+	return m.Mean(), m.n > 0
+}
+
+// Variance returns the population variance of the elements of `seq`.
+// Use Moments.SampleVariance for the unbiased sample variance.
+// It returns false if the sequence is empty.
+//
+// Example:
+//
+//	v, ok := stats.Variance(itertools.FromElements(2, 4, 4, 4, 5, 5, 7, 9))
+//	fmt.Println(v, ok) // 4 true
+func Variance[T it.Number](seq iter.Seq[T]) (float64, bool) {
+	m := moments(seq)
+	return m.Variance(), m.n > 0
+}
+
+// StdDev returns the population standard deviation of the elements of `seq`.
+// It returns false if the sequence is empty.
+//
+// Example:
+//
+//	sd, ok := stats.StdDev(itertools.FromElements(2, 4, 4, 4, 5, 5, 7, 9))
+//	fmt.Println(sd, ok) // 2 true
+func StdDev[T it.Number](seq iter.Seq[T]) (float64, bool) {
+	m := moments(seq)
+	return m.StdDev(), m.n > 0
+}
+
+// MinMax returns the smallest and the largest elements of `seq`.
+// NaN values are ignored. It returns false if there are no other elements.
+//
+// Example:
+//
+//	lo, hi, ok := stats.MinMax(itertools.FromElements(3, 1, 4, 1, 5))
+//	fmt.Println(lo, hi, ok) // 1 5 true
+func MinMax[T it.Number](seq iter.Seq[T]) (lo, hi T, ok bool) {
+	for v := range seq {
+		if v != v {
+			continue
+		}
+		if !ok {
+			lo, hi, ok = v, v, true
+			continue
+		}
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	return lo, hi, ok
+}
+
+const histogramPanicMessage = "stats: histogram bounds must be sorted in increasing order"
+
+// Histogram counts the elements of `seq` falling into the buckets separated by `bounds`,
+// which must be sorted in increasing order. The result has len(bounds)+1 counts:
+// the first one is for the elements less than bounds[0], the i-th one is for
+// the elements in [bounds[i-1], bounds[i]), and the last one is for the elements
+// greater than or equal to the last bound. NaN values aren't counted.
+//
+// It panics if `bounds` isn't sorted.
+//
+// Example:
+//
+//	counts := stats.Histogram(itertools.FromElements(1, 5, 10, 15, 20), 10, 20)
+//	fmt.Println(counts) // [2 2 1]
+func Histogram[T it.Number](seq iter.Seq[T], bounds ...float64) []int {
+	for i := 1; i < len(bounds); i++ {
+		if !(bounds[i-1] < bounds[i]) {
+			panic(histogramPanicMessage)
+		}
+	}
+
+	counts := make([]int, len(bounds)+1)
+	for v := range seq {
+		x := float64(v)
+		if math.IsNaN(x) {
+			continue
+		}
+		i, found := slices.BinarySearch(bounds, x)
+		if found {
+			i++
+		}
+		counts[i]++
+	}
+	return counts
+}
+
+const quantilePanicMessage = "stats: quantile must be in [0, 1]"
+
+// Quantile returns the exact `q`-quantile of the elements of `seq`,
+// interpolating linearly between the closest ranks.
+// All elements are collected and sorted, so memory grows with the length of `seq`;
+// see Digest for an approximation in bounded memory.
+// It returns false if the sequence is empty.
+//
+// It panics if `q` isn't in [0, 1].
+//
+// Example:
+//
+//	p90, ok := stats.Quantile(itertools.FromElements(1, 2, 3, 4, 5), 0.9)
+//	fmt.Println(p90, ok) // 4.6 true
+func Quantile[T it.Number](seq iter.Seq[T], q float64) (float64, bool) {
+	if !(q >= 0 && q <= 1) {
+		panic(quantilePanicMessage)
+	}
+
+	s := slices.Collect(it.Map(seq, func(v T) float64 { return float64(v) }))
+	if len(s) == 0 {
+		return math.NaN(), false
+	}
+	slices.Sort(s)
+
+	pos := q * float64(len(s)-1)
+	i := int(pos)
+	if i == len(s)-1 {
+		return s[i], true
+	}
+	frac := pos - float64(i)
+	return s[i] + (s[i+1]-s[i])*frac, true
+}
+
+// Median returns the exact median of the elements of `seq`.
+// It returns false if the sequence is empty.
+//
+// Example:
+//
+//	median, ok := stats.Median(itertools.FromElements(5, 1, 4, 2))
+//	fmt.Println(median, ok) // 3 true
+func Median[T it.Number](seq iter.Seq[T]) (float64, bool) {
+	return Quantile(seq, 0.5)
+}
+
+// running returns a sequence of the moments of `seq` after each of its elements.
+func running[T it.Number](seq iter.Seq[T]) iter.Seq[Moments] {
+	return it.Accumulate(seq, Moments{}, func(m Moments, v T) Moments {
+		m.Add(float64(v))
+		return m
+	})
+}
+
+// RunningMean returns a sequence of the means of `seq` computed after each of its elements.
+//
+// Example:
+//
+//	for mean := range stats.RunningMean(itertools.FromElements(2, 4, 9)) {
+//		fmt.Println(mean) // 2, 3, 5
+//	}
+func RunningMean[T it.Number](seq iter.Seq[T]) iter.Seq[float64] {
+	return it.Map(running(seq), Moments.Mean)
+}
+
+// RunningVariance returns a sequence of the population variances of `seq`
+// computed after each of its elements.
+//
+// Example:
+//
+//	for v := range stats.RunningVariance(itertools.FromElements(1, 3, 5)) {
+//		fmt.Println(v) // 0, 1, 2.6666666666666665
+//	}
+func RunningVariance[T it.Number](seq iter.Seq[T]) iter.Seq[float64] {
+	return it.Map(running(seq), Moments.Variance)
+}
+
+// RunningStdDev returns a sequence of the population standard deviations of `seq`
+// computed after each of its elements.
+func RunningStdDev[T it.Number](seq iter.Seq[T]) iter.Seq[float64] {
+	return it.Map(running(seq), Moments.StdDev)
+}
+
+// RunningMinMax returns a sequence of the smallest and the largest elements of `seq`
+// seen after each of its elements. NaN values are ignored.
+//
+// Example:
+//
+//	for lo, hi := range stats.RunningMinMax(itertools.FromElements(3, 1, 4)) {
+//		fmt.Println(lo, hi) // 3 3, 1 3, 1 4
+//	}
+func RunningMinMax[T it.Number](seq iter.Seq[T]) iter.Seq2[T, T] {
+	type bounds struct {
+		lo, hi T
+		ok     bool
+	}
+	acc := it.Accumulate(it.Filter(seq, func(v T) bool { return v == v }), bounds{},
+		func(b bounds, v T) bounds {
+			if !b.ok {
+				return bounds{v, v, true}
+			}
+			return bounds{min(b.lo, v), max(b.hi, v), true}
+		})
+	return func(yield func(T, T) bool) {
+		for b := range acc {
+			if !yield(b.lo, b.hi) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package stats
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	it "github.com/dzherb/go-itertools"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*max(1, math.Abs(a), math.Abs(b))
+}
+
+func TestMoments(t *testing.T) {
+	var m Moments
+	if got := m.Mean(); !math.IsNaN(got) {
+		t.Errorf("Moments.Mean() of an empty series = %v, want NaN", got)
+	}
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		m.Add(x)
+	}
+	if got := m.Count(); got != 8 {
+		t.Errorf("Moments.Count() = %v, want 8", got)
+	}
+	if got := m.Mean(); !approxEqual(got, 5) {
+		t.Errorf("Moments.Mean() = %v, want 5", got)
+	}
+	if got := m.Variance(); !approxEqual(got, 4) {
+		t.Errorf("Moments.Variance() = %v, want 4", got)
+	}
+	if got := m.SampleVariance(); !approxEqual(got, 32.0/7) {
+		t.Errorf("Moments.SampleVariance() = %v, want %v", got, 32.0/7)
+	}
+	if got := m.StdDev(); !approxEqual(got, 2) {
+		t.Errorf("Moments.StdDev() = %v, want 2", got)
+	}
+}
+
+func TestMomentsStability(t *testing.T) {
+	// A naive sum of squares loses all precision with a large offset.
+	var m Moments
+	for _, x := range []float64{4, 7, 13, 16} {
+		m.Add(1e9 + x)
+	}
+	if got := m.Variance(); !approxEqual(got, 22.5) {
+		t.Errorf("Moments.Variance() = %v, want 22.5", got)
+	}
+}
+
+func TestAggregators(t *testing.T) {
+	type testCase struct {
+		name   string
+		values []int
+		mean   float64
+		varnc  float64
+		stddev float64
+		ok     bool
+	}
+	tests := []testCase{
+		{
+			name:   "several values",
+			values: []int{2, 4, 4, 4, 5, 5, 7, 9},
+			mean:   5,
+			varnc:  4,
+			stddev: 2,
+			ok:     true,
+		},
+		{
+			name:   "single value",
+			values: []int{3},
+			mean:   3,
+			ok:     true,
+		},
+		{
+			name:   "empty",
+			values: nil,
+			ok:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq := slices.Values(tt.values)
+			if got, ok := Mean(seq); ok != tt.ok || (ok && !approxEqual(got, tt.mean)) {
+				t.Errorf("Mean() = %v, %v, want %v, %v", got, ok, tt.mean, tt.ok)
+			}
+			if got, ok := Variance(seq); ok != tt.ok || (ok && !approxEqual(got, tt.varnc)) {
+				t.Errorf("Variance() = %v, %v, want %v, %v", got, ok, tt.varnc, tt.ok)
+			}
+			if got, ok := StdDev(seq); ok != tt.ok || (ok && !approxEqual(got, tt.stddev)) {
+				t.Errorf("StdDev() = %v, %v, want %v, %v", got, ok, tt.stddev, tt.ok)
+			}
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	lo, hi, ok := MinMax(it.FromElements(3, 1, 4, 1, 5))
+	if lo != 1 || hi != 5 || !ok {
+		t.Errorf("MinMax() = %v, %v, %v, want 1, 5, true", lo, hi, ok)
+	}
+
+	flo, fhi, ok := MinMax(it.FromElements(math.NaN(), 2.5, -1, math.NaN()))
+	if flo != -1 || fhi != 2.5 || !ok {
+		t.Errorf("MinMax() = %v, %v, %v, want -1, 2.5, true", flo, fhi, ok)
+	}
+
+	if _, _, ok := MinMax(it.FromElements(math.NaN())); ok {
+		t.Errorf("MinMax() of NaN only = true, want false")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	type testCase struct {
+		name   string
+		values []float64
+		bounds []float64
+		want   []int
+	}
+	tests := []testCase{
+		{
+			name:   "two bounds",
+			values: []float64{1, 5, 10, 15, 20, 25},
+			bounds: []float64{10, 20},
+			want:   []int{2, 2, 2},
+		},
+		{
+			name:   "no bounds",
+			values: []float64{1, 2, 3},
+			bounds: nil,
+			want:   []int{3},
+		},
+		{
+			name:   "NaN is skipped",
+			values: []float64{math.NaN(), 0.5},
+			bounds: []float64{0, 1},
+			want:   []int{0, 1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Histogram(slices.Values(tt.values), tt.bounds...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Histogram() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistogramUnsortedBounds(t *testing.T) {
+	defer func() {
+		if r := recover(); r != histogramPanicMessage {
+			t.Errorf("Histogram() panicked with %v, want %q", r, histogramPanicMessage)
+		}
+	}()
+	Histogram(it.FromElements(1), 2, 1)
+}
+
+func TestQuantile(t *testing.T) {
+	type testCase struct {
+		name   string
+		values []int
+		q      float64
+		want   float64
+		ok     bool
+	}
+	tests := []testCase{
+		{
+			name:   "p90",
+			values: []int{5, 1, 4, 2, 3},
+			q:      0.9,
+			want:   4.6,
+			ok:     true,
+		},
+		{
+			name:   "min",
+			values: []int{5, 1, 4},
+			q:      0,
+			want:   1,
+			ok:     true,
+		},
+		{
+			name:   "max",
+			values: []int{5, 1, 4},
+			q:      1,
+			want:   5,
+			ok:     true,
+		},
+		{
+			name:   "empty",
+			values: nil,
+			q:      0.5,
+			ok:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Quantile(slices.Values(tt.values), tt.q)
+			if ok != tt.ok || (ok && !approxEqual(got, tt.want)) {
+				t.Errorf("Quantile() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	defer func() {
+		if r := recover(); r != quantilePanicMessage {
+			t.Errorf("Quantile() panicked with %v, want %q", r, quantilePanicMessage)
+		}
+	}()
+	Quantile(it.FromElements(1), 1.5)
+}
+
+func TestMedian(t *testing.T) {
+	if got, ok := Median(it.FromElements(5, 1, 4, 2)); got != 3 || !ok {
+		t.Errorf("Median() = %v, %v, want 3, true", got, ok)
+	}
+	if got, ok := Median(it.FromElements(5, 1, 4)); got != 4 || !ok {
+		t.Errorf("Median() = %v, %v, want 4, true", got, ok)
+	}
+}
+
+func TestRunning(t *testing.T) {
+	if got, want := slices.Collect(RunningMean(it.FromElements(2, 4, 9))), []float64{2, 3, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RunningMean() = %v, want %v", got, want)
+	}
+
+	variances := slices.Collect(RunningVariance(it.FromElements(1, 3, 5)))
+	for i, want := range []float64{0, 1, 8.0 / 3} {
+		if !approxEqual(variances[i], want) {
+			t.Errorf("RunningVariance() = %v, want %v at %d", variances[i], want, i)
+		}
+	}
+
+	stddevs := slices.Collect(RunningStdDev(it.FromElements(1, 3)))
+	if want := []float64{0, 1}; !reflect.DeepEqual(stddevs, want) {
+		t.Errorf("RunningStdDev() = %v, want %v", stddevs, want)
+	}
+
+	var los, his []int
+	for lo, hi := range RunningMinMax(it.FromElements(3, 1, 4)) {
+		los, his = append(los, lo), append(his, hi)
+	}
+	if want := []int{3, 1, 1}; !reflect.DeepEqual(los, want) {
+		t.Errorf("RunningMinMax() mins = %v, want %v", los, want)
+	}
+	if want := []int{3, 3, 4}; !reflect.DeepEqual(his, want) {
+		t.Errorf("RunningMinMax() maxes = %v, want %v", his, want)
+	}
+}
+
+func TestRunningIsLazy(t *testing.T) {
+	// Running statistics of an infinite sequence can still be consumed partially.
+	means := slices.Collect(it.Take(RunningMean(it.Count(1, 1)), 3))
+	if want := []float64{1, 1.5, 2}; !reflect.DeepEqual(means, want) {
+		t.Errorf("RunningMean() = %v, want %v", means, want)
+	}
+}
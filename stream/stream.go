@@ -1,7 +1,9 @@
 package stream
 
 import (
+	"fmt"
 	it "github.com/dzherb/go-itertools"
+	"io"
 	"iter"
 	"slices"
 )
@@ -20,6 +22,12 @@ func FromChan[V any](ch <-chan V) Stream[V] {
 	return FromIterator(it.FromChan(ch))
 }
 
+// FromResults creates a stream from a sequence of (value, error) pairs.
+// The stream ends at the first non-nil error, which is stored in `err`.
+func FromResults[V any](iter iter.Seq2[V, error], err *error) Stream[V] {
+	return FromIterator(it.UntilError(iter, err))
+}
+
 func (s Stream[V]) Iterator() iter.Seq[V] {
 	return iter.Seq[V](s)
 }
@@ -36,6 +44,14 @@ func (s Stream[V]) Take(n int) Stream[V] {
 	return FromIterator(it.Take[V](s.Iterator(), n))
 }
 
+func (s Stream[V]) Drop(n int) Stream[V] {
+	return FromIterator(it.Drop[V](s.Iterator(), n))
+}
+
+func (s Stream[V]) Last(n int) Stream[V] {
+	return FromIterator(it.Last[V](s.Iterator(), n))
+}
+
 func (s Stream[V]) TakeWhile(predicate func(V) bool) Stream[V] {
 	return FromIterator(it.TakeWhile[V](s.Iterator(), predicate))
 }
@@ -44,6 +60,12 @@ func (s Stream[V]) DropWhile(predicate func(V) bool) Stream[V] {
 	return FromIterator(it.DropWhile[V](s.Iterator(), predicate))
 }
 
+// Once returns a stream that can only be consumed once.
+// It panics if an attempt to iterate over the stream a second time is made.
+func (s Stream[V]) Once() Stream[V] {
+	return FromIterator(it.Once[V](s.Iterator()))
+}
+
 func (s Stream[V]) Filter(predicate func(V) bool) Stream[V] {
 	return FromIterator(it.Filter[V](s.Iterator(), predicate))
 }
@@ -65,3 +87,12 @@ func Map[V, R any](s Stream[V], mapper func(V) R) Stream[R] {
 func (s Stream[V]) ForEach(consumer func(V)) {
 	it.ForEach(s.Iterator(), consumer)
 }
+
+// WriteTo writes every element of the stream to `w` on its own line,
+// formatted with the default format of the fmt package.
+// It implements io.WriterTo.
+func (s Stream[V]) WriteTo(w io.Writer) (int64, error) {
+	return it.WriteFormatted(w, s.Iterator(), func(v V) string {
+		return fmt.Sprintln(v)
+	})
+}
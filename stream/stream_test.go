@@ -1,7 +1,11 @@
 package stream
 
 import (
+	"bufio"
+	"errors"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -64,6 +68,24 @@ func TestSlice(t *testing.T) {
 	}
 }
 
+func TestDrop(t *testing.T) {
+	s := FromElements(10, 20, 30, 40)
+	got := s.Drop(3).Collect()
+	want := []int{40}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Drop().Collect() = %v; want %v", got, want)
+	}
+}
+
+func TestLast(t *testing.T) {
+	s := FromElements(10, 20, 30, 40)
+	got := s.Last(2).Collect()
+	want := []int{30, 40}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Last().Collect() = %v; want %v", got, want)
+	}
+}
+
 func TestTakeWhile(t *testing.T) {
 	s := FromElements(1, 2, 3, 4, 1, 0)
 	tw := s.TakeWhile(func(v int) bool { return v < 4 })
@@ -108,3 +130,62 @@ func TestFromChan(t *testing.T) {
 		t.Errorf("FromChan().Collect() = %v; want %v", got, want)
 	}
 }
+
+func TestWriteTo(t *testing.T) {
+	s := FromElements(1, 2, 3)
+	var sb strings.Builder
+	n, err := s.WriteTo(&sb)
+	if err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if n != int64(sb.Len()) {
+		t.Errorf("WriteTo() n = %d; want %d", n, sb.Len())
+	}
+
+	var got []int
+	scanner := bufio.NewScanner(strings.NewReader(sb.String()))
+	for scanner.Scan() {
+		v, err := strconv.Atoi(scanner.Text())
+		if err != nil {
+			t.Fatalf("unexpected line %q", scanner.Text())
+		}
+		got = append(got, v)
+	}
+	want := []int{1, 2, 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteTo() round trip = %v; want %v", got, want)
+	}
+}
+
+func TestFromResults(t *testing.T) {
+	errBoom := errors.New("boom")
+	seq := func(yield func(int, error) bool) {
+		_ = yield(1, nil) && yield(2, nil) && yield(0, errBoom)
+	}
+
+	var err error
+	got := FromResults(seq, &err).Collect()
+	want := []int{1, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromResults().Collect() = %v; want %v", got, want)
+	}
+	if err != errBoom {
+		t.Errorf("FromResults() err = %v; want %v", err, errBoom)
+	}
+}
+
+func TestOnce(t *testing.T) {
+	s := FromElements(1, 2, 3).Once()
+	got := s.Collect()
+	want := []int{1, 2, 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Once().Collect() = %v; want %v", got, want)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic, but did not get one")
+		}
+	}()
+	s.Collect()
+}
@@ -0,0 +1,73 @@
+package itertools
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// WriteFormatted writes every element of `iter` to `w`, using `format`
+// to turn each element into its textual representation.
+// No delimiters are added, so `format` is responsible for them.
+//
+// The output is buffered. Iteration stops at the first write error,
+// which is returned together with the number of bytes written.
+//
+// Example:
+//
+//	seq := FromElements(1, 2, 3)
+//	WriteFormatted(os.Stdout, seq, func(v int) string {
+//		return fmt.Sprintf("<%d>", v)
+//	}) // <1><2><3>
+func WriteFormatted[V any](w io.Writer, iter iter.Seq[V], format func(V) string) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for v := range iter {
+		if _, err := bw.WriteString(format(v)); err != nil {
+			return cw.n, err
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// WriteLines writes every element of `iter` to `w`,
+// terminating each of them with a newline.
+//
+// Example:
+//
+//	WriteLines(os.Stdout, FromElements("a", "b", "c")) // "a\nb\nc\n"
+func WriteLines(w io.Writer, iter iter.Seq[string]) (int64, error) {
+	return WriteFormatted(w, iter, func(s string) string {
+		return s + "\n"
+	})
+}
+
+// WriteJoined writes the elements of `iter` to `w`,
+// separating consecutive elements with `sep`.
+//
+// Example:
+//
+//	WriteJoined(os.Stdout, FromElements("a", "b", "c"), ", ") // "a, b, c"
+func WriteJoined(w io.Writer, iter iter.Seq[string], sep string) (int64, error) {
+	first := true
+	return WriteFormatted(w, iter, func(s string) string {
+		if first {
+			first = false
+			return s
+		}
+		return sep + s
+	})
+}
+
+// countingWriter counts the bytes successfully written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,143 @@
+package itertools
+
+import (
+	"bufio"
+	"errors"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type failingWriter struct {
+	err error
+}
+
+func (fw failingWriter) Write(p []byte) (int, error) {
+	return 0, fw.err
+}
+
+func TestWriteLines(t *testing.T) {
+	type testCase struct {
+		name string
+		iter iter.Seq[string]
+		want string
+	}
+	tests := []testCase{
+		{
+			name: "simple",
+			iter: FromElements("a", "b", "c"),
+			want: "a\nb\nc\n",
+		},
+		{
+			name: "empty",
+			iter: FromElements[string](),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			n, err := WriteLines(&sb, tt.iter)
+			if err != nil {
+				t.Fatalf("WriteLines() error = %v", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("WriteLines() = %q, want %q", got, tt.want)
+			}
+			if n != int64(len(tt.want)) {
+				t.Errorf("WriteLines() n = %d, want %d", n, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestWriteLinesRoundTrip(t *testing.T) {
+	want := []string{"first", "", "third line"}
+
+	var sb strings.Builder
+	if _, err := WriteLines(&sb, slices.Values(want)); err != nil {
+		t.Fatalf("WriteLines() error = %v", err)
+	}
+
+	var got []string
+	scanner := bufio.NewScanner(strings.NewReader(sb.String()))
+	for scanner.Scan() {
+		got = append(got, scanner.Text())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteLines() round trip = %q, want %q", got, want)
+	}
+}
+
+func TestWriteJoined(t *testing.T) {
+	type testCase struct {
+		name string
+		iter iter.Seq[string]
+		sep  string
+		want string
+	}
+	tests := []testCase{
+		{
+			name: "comma",
+			iter: FromElements("a", "b", "c"),
+			sep:  ", ",
+			want: "a, b, c",
+		},
+		{
+			name: "single",
+			iter: FromElements("a"),
+			sep:  ", ",
+			want: "a",
+		},
+		{
+			name: "empty",
+			iter: FromElements[string](),
+			sep:  ", ",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if _, err := WriteJoined(&sb, tt.iter, tt.sep); err != nil {
+				t.Fatalf("WriteJoined() error = %v", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("WriteJoined() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFormatted(t *testing.T) {
+	var sb strings.Builder
+	_, err := WriteFormatted(&sb, FromElements(1, 2, 3), func(v int) string {
+		return "<" + strconv.Itoa(v) + ">"
+	})
+	if err != nil {
+		t.Fatalf("WriteFormatted() error = %v", err)
+	}
+	if got, want := sb.String(), "<1><2><3>"; got != want {
+		t.Errorf("WriteFormatted() = %q, want %q", got, want)
+	}
+}
+
+func TestWriteFormattedStopsOnError(t *testing.T) {
+	wantErr := errors.New("disk full")
+	line := strings.Repeat("x", 1024)
+
+	consumed := 0
+	_, err := WriteFormatted(failingWriter{wantErr}, Count(0, 1), func(int) string {
+		consumed++
+		return line
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("WriteFormatted() error = %v, want %v", err, wantErr)
+	}
+	if consumed > 10 {
+		t.Errorf("WriteFormatted() consumed %d elements after the error", consumed)
+	}
+}